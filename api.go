@@ -1,9 +1,17 @@
 package gonic
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"image"
+	"io/fs"
 	"log"
+	"net/http"
 	"os"
+	"runtime"
+	"sync"
+	"time"
 
 	"gonic/components"
 	"gonic/internal"
@@ -23,6 +31,8 @@ const (
 	NativeMode RenderMode = RenderMode(shared.NativeMode)
 	// AutoMode detects the best renderer based on environment
 	AutoMode RenderMode = RenderMode(shared.AutoMode)
+	// HeadlessMode runs without a display or listening port, for servers and tests
+	HeadlessMode RenderMode = RenderMode(shared.HeadlessMode)
 )
 
 // Direction is type alias for shared.Direction
@@ -35,12 +45,97 @@ const (
 	Vertical Direction = Direction(shared.Vertical)
 )
 
+// Option is type alias for components.Option
+type Option = components.Option
+
+// Tab is type alias for components.Tab
+type Tab = components.Tab
+
+// Shortcut is type alias for components.Shortcut
+type Shortcut = components.Shortcut
+
+// KeyCode is type alias for internal.KeyCode
+type KeyCode = internal.KeyCode
+
+// KeyModifiers is type alias for internal.KeyModifiers
+type KeyModifiers = internal.KeyModifiers
+
+// ColumnWidth is type alias for layout.ColumnWidth
+type ColumnWidth = layout.ColumnWidth
+
+// FlexChild is type alias for layout.FlexChild
+type FlexChild = layout.FlexChild
+
+// ScrollDirection is type alias for layout.ScrollDirection
+type ScrollDirection = layout.ScrollDirection
+
+const (
+	// ScrollVertical scrolls up and down
+	ScrollVertical = layout.ScrollVertical
+	// ScrollHorizontal scrolls sideways
+	ScrollHorizontal = layout.ScrollHorizontal
+	// ScrollBoth scrolls both ways
+	ScrollBoth = layout.ScrollBoth
+)
+
+// Keys that menu shortcuts can use
+const (
+	KeyA         = internal.KeyA
+	KeyB         = internal.KeyB
+	KeyC         = internal.KeyC
+	KeyD         = internal.KeyD
+	KeyE         = internal.KeyE
+	KeyF         = internal.KeyF
+	KeyG         = internal.KeyG
+	KeyH         = internal.KeyH
+	KeyI         = internal.KeyI
+	KeyJ         = internal.KeyJ
+	KeyK         = internal.KeyK
+	KeyL         = internal.KeyL
+	KeyM         = internal.KeyM
+	KeyN         = internal.KeyN
+	KeyO         = internal.KeyO
+	KeyP         = internal.KeyP
+	KeyQ         = internal.KeyQ
+	KeyR         = internal.KeyR
+	KeyS         = internal.KeyS
+	KeyT         = internal.KeyT
+	KeyU         = internal.KeyU
+	KeyV         = internal.KeyV
+	KeyW         = internal.KeyW
+	KeyX         = internal.KeyX
+	KeyY         = internal.KeyY
+	KeyZ         = internal.KeyZ
+	KeyEscape    = internal.KeyEscape
+	KeyEnter     = internal.KeyEnter
+	KeySpace     = internal.KeySpace
+	KeyBackspace = internal.KeyBackspace
+	KeyTab       = internal.KeyTab
+)
+
+// Modifier keys that menu shortcuts can use
+const (
+	ModShift = internal.ModShift
+	ModCtrl  = internal.ModCtrl
+	ModAlt   = internal.ModAlt
+	ModSuper = internal.ModSuper
+)
+
 // App represents a Gonic application
 type App struct {
-	config       *shared.Config
-	windows      []*Window
-	webRenderer  *WebRenderer
-	nativeActive bool
+	config         *shared.Config
+	windows        []*Window
+	webRenderer    *WebRenderer
+	nativeRenderer *NativeRenderer
+	nativeActive   bool
+	headless       bool
+	basePath       string
+
+	// Shutdown handling
+	quit          chan struct{}
+	quitOnce      sync.Once
+	shutdownHooks []func()
+	hooksMu       sync.Mutex
 }
 
 // Config is a more user-friendly version of shared.Config
@@ -49,7 +144,8 @@ type Config struct {
 	Width      int
 	Height     int
 	RenderMode RenderMode
-	Port       int // Used for web renderer
+	Port       int    // Used for web renderer
+	BasePath   string // URL path the web renderer is served under, "/" by default
 }
 
 // NewApp creates a new Gonic application with default configuration
@@ -75,73 +171,250 @@ func NewAppWithConfig(config *Config) *App {
 	}
 
 	app := &App{
-		config:  sharedConfig,
-		windows: make([]*Window, 0),
+		config:   sharedConfig,
+		windows:  make([]*Window, 0),
+		basePath: config.BasePath,
+		quit:     make(chan struct{}),
 	}
 
-	// Initialize the appropriate renderer
-	mode := shared.RenderMode(config.RenderMode)
-	if mode == shared.WebMode {
-		app.webRenderer = NewWebRenderer(config.Port)
-	} else if mode == shared.NativeMode {
+	// Initialize the appropriate renderer. The native renderer is only
+	// initialized when it is actually going to be used.
+	switch shared.RenderMode(config.RenderMode) {
+	case shared.WebMode:
+		app.useWebRenderer()
+	case shared.HeadlessMode:
+		app.headless = true
+		app.useWebRenderer()
+	case shared.NativeMode:
 		app.nativeActive = tryNativeRenderer()
 		if !app.nativeActive {
 			fmt.Println("Native renderer not available, falling back to web renderer")
-			app.webRenderer = NewWebRenderer(config.Port)
+			app.useWebRenderer()
 		}
-	} else { // AutoMode
+	default: // AutoMode
 		// Try native first, then fall back to web
 		app.nativeActive = tryNativeRenderer()
 		if !app.nativeActive {
-			app.webRenderer = NewWebRenderer(config.Port)
+			app.useWebRenderer()
 		}
 	}
 
+	if app.nativeActive {
+		app.nativeRenderer = NewNativeRenderer(internal.CurrentRenderer.(*internal.FyneRenderer))
+	}
+
 	// Set as current app for global access
 	currentApp = app
 
 	return app
 }
 
+// useWebRenderer switches the application to the web renderer
+func (a *App) useWebRenderer() {
+	a.nativeActive = false
+	if a.webRenderer == nil {
+		a.webRenderer = NewWebRenderer(a.config.Port)
+		if a.basePath != "" {
+			a.webRenderer.SetBasePath(a.basePath)
+		}
+	}
+	a.webRenderer.SetWindows(a.windows)
+}
+
 // AddWindow adds a window to the application
 func (a *App) AddWindow(window *Window) {
 	a.windows = append(a.windows, window)
+	if a.webRenderer != nil {
+		a.webRenderer.SetWindows(a.windows)
+	}
+}
+
+// Handler returns the application's web UI as an http.Handler so it can be
+// mounted in an existing server under Config.BasePath. Calling Handler
+// switches the application to the web renderer.
+func (a *App) Handler() http.Handler {
+	a.useWebRenderer()
+	return a.webRenderer.Handler()
 }
 
-// Run starts the application and displays all windows
+// EnableSessions gives every browser its own instance of the UI, built by
+// factory when the browser first connects. Sessions idle for longer than ttl
+// are ended. Sessions only apply to the web renderer.
+func (a *App) EnableSessions(factory SessionFactory, ttl time.Duration) {
+	if a.webRenderer == nil {
+		fmt.Println("Sessions are only supported by the web renderer")
+		return
+	}
+	a.webRenderer.EnableSessions(factory, ttl)
+}
+
+// OnSessionEnd registers a hook that is called once for every browser
+// session, after it expires or the app quits
+func (a *App) OnSessionEnd(hook func(session *Session)) {
+	if a.webRenderer != nil {
+		a.webRenderer.OnSessionEnd(hook)
+	}
+}
+
+// Run starts the application and displays all windows.
+// It returns after Quit is called and exits the program if the renderer fails.
 func (a *App) Run() {
-	// Verify we have at least one window
-	if len(a.windows) == 0 {
-		log.Fatal("Error: No windows to display. Create a window with NewWindow() first.")
+	if err := a.RunContext(context.Background()); err != nil {
+		log.Fatal("Error: ", err)
+	}
+}
+
+// RunContext starts the application and blocks until the context is done, Quit
+// is called or the renderer stops. The renderer is shut down and the shutdown
+// hooks are run before it returns.
+func (a *App) RunContext(ctx context.Context) error {
+	// Verify we have at least one window, unless each session builds its own
+	sessions := a.webRenderer != nil && a.webRenderer.sessionsEnabled()
+	if len(a.windows) == 0 && !sessions {
+		return errors.New("no windows to display, create a window with NewWindow() first")
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-a.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	// Run with the active renderer
-	if a.nativeActive {
+	var err error
+	if a.headless {
+		// Nothing to display, wait until the application is stopped
+		fmt.Println("Starting Gonic in headless mode...")
+		<-ctx.Done()
+	} else if a.nativeActive {
 		// Run with native renderer
 		fmt.Println("Starting Gonic in native mode...")
-		runNativeApp(a.windows)
+		err = a.nativeRenderer.RunContext(ctx, a.windows)
 	} else {
 		// Run with web renderer
-		fmt.Printf("Starting Gonic in web mode. Open your browser at http://localhost:%d\n", a.config.Port)
-		a.webRenderer.Run(a.windows)
+		fmt.Printf("Starting Gonic in web mode. Open your browser at http://localhost:%d%s\n", a.config.Port, a.webRenderer.BasePath())
+		err = a.webRenderer.RunContext(ctx, a.windows)
 	}
+
+	a.shutdown()
+	return err
 }
 
-// ShowDialog displays a dialog with the given title, message, and buttons
+// Quit stops a running application, making Run and RunContext return
+func (a *App) Quit() {
+	a.quitOnce.Do(func() {
+		close(a.quit)
+	})
+}
+
+// OnShutdown registers a hook that is called when the application stops
+func (a *App) OnShutdown(hook func()) {
+	a.hooksMu.Lock()
+	a.shutdownHooks = append(a.shutdownHooks, hook)
+	a.hooksMu.Unlock()
+}
+
+// shutdown runs the shutdown hooks and shuts down the renderer backend
+func (a *App) shutdown() {
+	a.hooksMu.Lock()
+	hooks := append([]func(){}, a.shutdownHooks...)
+	a.hooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+	internal.ShutdownRenderer()
+}
+
+// unanswerable reports whether dialogs can't be answered: headless apps have
+// no one to ask until a browser connects to their handler
+func (a *App) unanswerable() bool {
+	return a.headless && !a.webRenderer.connected()
+}
+
+// ShowDialog displays a dialog with the given title, message, and buttons.
+// It blocks until a button is clicked and returns the button's index. In
+// headless mode without a connected browser it returns -1 straight away.
 func (a *App) ShowDialog(title, message string, buttons []string) int {
+	if a.unanswerable() {
+		return -1
+	}
 	if a.nativeActive {
-		return showNativeDialog(title, message, buttons)
+		return a.nativeRenderer.ShowDialog(title, message, buttons)
 	} else {
 		return a.webRenderer.ShowDialog(title, message, buttons)
 	}
 }
 
+// ShowDialogContext displays a dialog and waits until a button is clicked or the
+// context is done, in which case it returns -1 and the context's error. In
+// headless mode without a connected browser it returns -1 and ErrNoClients
+// straight away.
+func (a *App) ShowDialogContext(ctx context.Context, title, message string, buttons []string) (int, error) {
+	if a.unanswerable() {
+		return -1, ErrNoClients
+	}
+	if a.nativeActive {
+		return a.nativeRenderer.ShowDialogContext(ctx, title, message, buttons)
+	}
+	return a.webRenderer.ShowDialogContext(ctx, title, message, buttons)
+}
+
+// Confirm asks a yes/no question and reports whether it was confirmed. In
+// headless mode without a connected browser it returns false straight away.
+func (a *App) Confirm(title, message string) bool {
+	if a.unanswerable() {
+		return false
+	}
+	if a.nativeActive {
+		confirmed, _ := a.nativeRenderer.ConfirmContext(context.Background(), title, message)
+		return confirmed
+	}
+	return a.webRenderer.ShowDialog(title, message, []string{"Cancel", "OK"}) == 1
+}
+
+// ShowError displays an error and waits until it is dismissed. In headless
+// mode without a connected browser it returns straight away.
+func (a *App) ShowError(err error) {
+	if a.unanswerable() {
+		return
+	}
+	if a.nativeActive {
+		_ = a.nativeRenderer.ShowErrorContext(context.Background(), err)
+		return
+	}
+	a.webRenderer.ShowDialog("Error", err.Error(), []string{"OK"})
+}
+
+// Prompt asks for a line of text. It returns the text and whether OK was
+// clicked. In headless mode without a connected browser it returns "" and
+// false straight away.
+func (a *App) Prompt(title, message, placeholder string) (string, bool) {
+	if a.unanswerable() {
+		return "", false
+	}
+	var text string
+	var ok bool
+	if a.nativeActive {
+		text, ok, _ = a.nativeRenderer.PromptContext(context.Background(), title, message, placeholder)
+	} else {
+		text, ok, _ = a.webRenderer.PromptContext(context.Background(), title, message, placeholder)
+	}
+	return text, ok
+}
+
 // Window represents a window in the application
 type Window struct {
-	title   string
-	width   int
-	height  int
-	content shared.Layout
+	title        string
+	width        int
+	height       int
+	content      shared.Layout
+	menus        []*components.Menu
+	contextMenus map[shared.Component]*components.Menu
 }
 
 // NewWindow creates a new window with the given title, width, and height
@@ -188,6 +461,34 @@ func (w *Window) Content() shared.Layout {
 	return w.content
 }
 
+// SetMainMenu sets the menus shown in the window's menu bar
+func (w *Window) SetMainMenu(menus ...*components.Menu) {
+	w.menus = menus
+}
+
+// MainMenu returns the menus shown in the window's menu bar
+func (w *Window) MainMenu() []*components.Menu {
+	return w.menus
+}
+
+// SetContextMenu sets the menu shown when a component in the window is
+// right-clicked. A nil menu removes it.
+func (w *Window) SetContextMenu(c shared.Component, menu *components.Menu) {
+	if w.contextMenus == nil {
+		w.contextMenus = make(map[shared.Component]*components.Menu)
+	}
+	if menu == nil {
+		delete(w.contextMenus, c)
+	} else {
+		w.contextMenus[c] = menu
+	}
+}
+
+// ContextMenu returns the menu shown when a component is right-clicked, or nil
+func (w *Window) ContextMenu(c shared.Component) *components.Menu {
+	return w.contextMenus[c]
+}
+
 // Global app for dialog access
 var currentApp *App
 
@@ -200,11 +501,34 @@ func ShowDialog(title, message string, buttons []string) int {
 	return currentApp.ShowDialog(title, message, buttons)
 }
 
+// ShowDialogContext displays a dialog and waits until a button is clicked or the context is done
+func ShowDialogContext(ctx context.Context, title, message string, buttons []string) (int, error) {
+	if currentApp == nil {
+		return -1, errors.New("no active application for dialog")
+	}
+	return currentApp.ShowDialogContext(ctx, title, message, buttons)
+}
+
 // Native renderer functions that may be implemented elsewhere
 
-// tryNativeRenderer attempts to initialize the native renderer
-// Returns true if successful, false otherwise
+// nativeMu guards the lazy initialization of the native renderer
+var nativeMu sync.Mutex
+
+// tryNativeRenderer initializes the native renderer on first use.
+// Returns true if it is available, false otherwise
 func tryNativeRenderer() bool {
+	nativeMu.Lock()
+	defer nativeMu.Unlock()
+
+	// Reuse the renderer of an earlier app instead of creating a second Fyne app
+	if _, ok := internal.CurrentRenderer.(*internal.FyneRenderer); ok {
+		return true
+	}
+
+	if !displayAvailable() {
+		return false
+	}
+
 	// Try to initialize the Fyne renderer
 	renderer := internal.NewFyneRenderer()
 	err := renderer.Initialize()
@@ -218,27 +542,23 @@ func tryNativeRenderer() bool {
 	return true
 }
 
-// runNativeApp runs the application with the native renderer
-func runNativeApp(windows []*Window) {
-	// This would be implemented by the native renderer
-	fmt.Println("Native renderer not implemented, falling back to web renderer")
-	NewWebRenderer(8080).Run(windows)
-}
-
-// showNativeDialog displays a dialog with the native renderer
-func showNativeDialog(title, message string, buttons []string) int {
-	// This would be implemented by the native renderer
-	fmt.Println("Native dialog not implemented, falling back to web renderer")
-	return 0
+// displayAvailable reports whether there is a display to open native windows
+// on. Only X11 and Wayland systems can run without one.
+func displayAvailable() bool {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+	}
+	return true
 }
 
-// Initialize the library with the Fyne renderer
-func init() {
-	internal.CurrentRenderer = internal.NewFyneRenderer()
-	err := internal.InitializeRenderer()
-	if err != nil {
-		panic("Failed to initialize renderer: " + err.Error())
+// runNativeApp runs the application with the native renderer until the context is done
+func runNativeApp(ctx context.Context, windows []*Window) error {
+	if !tryNativeRenderer() {
+		return errors.New("native renderer not available")
 	}
+	backend := internal.CurrentRenderer.(*internal.FyneRenderer)
+	return NewNativeRenderer(backend).RunContext(ctx, windows)
 }
 
 // NewLabel creates a new label component.
@@ -256,6 +576,121 @@ func NewSpacer(size int) *components.Spacer {
 	return components.NewSpacer(size)
 }
 
+// NewTextInput creates a new single-line text input.
+func NewTextInput(placeholder string) *components.TextInput {
+	return components.NewTextInput(placeholder)
+}
+
+// NewPasswordInput creates a new password input.
+func NewPasswordInput(placeholder string) *components.TextInput {
+	return components.NewPasswordInput(placeholder)
+}
+
+// NewMultiLineInput creates a new multi-line text input.
+func NewMultiLineInput(placeholder string) *components.TextInput {
+	return components.NewMultiLineInput(placeholder)
+}
+
+// NewCheckbox creates a new checkbox component.
+func NewCheckbox(label string, onChange func(checked bool)) *components.Checkbox {
+	return components.NewCheckbox(label, onChange)
+}
+
+// NewSwitch creates a new switch component.
+func NewSwitch(label string, onChange func(on bool)) *components.Switch {
+	return components.NewSwitch(label, onChange)
+}
+
+// NewRadioGroup creates a new radio group component.
+func NewRadioGroup(options []string, onChange func(index int, option string)) *components.RadioGroup {
+	return components.NewRadioGroup(options, onChange)
+}
+
+// NewSelect creates a new dropdown component.
+func NewSelect(options []Option, onSelect func(option Option)) *components.Select {
+	return components.NewSelect(options, onSelect)
+}
+
+// NewComboBox creates a new combo box component.
+func NewComboBox(options []Option, onSelect func(option Option)) *components.ComboBox {
+	return components.NewComboBox(options, onSelect)
+}
+
+// NewProgressBar creates a new progress bar component.
+func NewProgressBar() *components.ProgressBar {
+	return components.NewProgressBar()
+}
+
+// NewSlider creates a new slider component.
+func NewSlider(min, max float64, onChange func(value float64)) *components.Slider {
+	return components.NewSlider(min, max, onChange)
+}
+
+// NewTable creates a new table component.
+func NewTable(columns []components.TableColumn, provider components.TableRowProvider) *components.Table {
+	return components.NewTable(columns, provider)
+}
+
+// NewList creates a new list component whose items are built by item.
+func NewList(length int, item func(index int) string) *components.List {
+	return components.NewList(length, item)
+}
+
+// NewTree creates a new tree component whose nodes are loaded by loadChildren.
+func NewTree(loadChildren components.TreeChildrenFunc) *components.Tree {
+	return components.NewTree(loadChildren)
+}
+
+// NewTabs creates a new tabs container with the given tabs.
+func NewTabs(tabs ...Tab) *components.Tabs {
+	return components.NewTabs(tabs...)
+}
+
+// NewNavigator creates a new navigator showing the given root page.
+func NewNavigator(title string, root shared.Layout) *components.Navigator {
+	return components.NewNavigator(title, root)
+}
+
+// NewImageFromFile creates a new image loaded from a file on disk.
+func NewImageFromFile(file string) *components.Image {
+	return components.NewImageFromFile(file)
+}
+
+// NewImageFromFS creates a new image loaded from a file in a file system, such as an embed.FS.
+func NewImageFromFS(fsys fs.FS, name string) *components.Image {
+	return components.NewImageFromFS(fsys, name)
+}
+
+// NewImageFromImage creates a new image showing an image.Image value.
+func NewImageFromImage(img image.Image) *components.Image {
+	return components.NewImageFromImage(img)
+}
+
+// NewMenu creates a new menu with the given label and items.
+func NewMenu(label string, items ...*components.MenuItem) *components.Menu {
+	return components.NewMenu(label, items...)
+}
+
+// NewMenuItem creates a new menu item that calls action when clicked.
+func NewMenuItem(label string, action func()) *components.MenuItem {
+	return components.NewMenuItem(label, action)
+}
+
+// NewCheckMenuItem creates a new menu item with a check mark that the user can toggle.
+func NewCheckMenuItem(label string, onToggle func(checked bool)) *components.MenuItem {
+	return components.NewCheckMenuItem(label, onToggle)
+}
+
+// NewSubmenuItem creates a new menu item that opens a submenu.
+func NewSubmenuItem(label string, submenu *components.Menu) *components.MenuItem {
+	return components.NewSubmenuItem(label, submenu)
+}
+
+// NewMenuSeparator creates a new line separating groups of menu items.
+func NewMenuSeparator() *components.MenuItem {
+	return components.NewMenuSeparator()
+}
+
 // NewStackLayout creates a new stack layout.
 func NewStackLayout() *layout.StackLayout {
 	return layout.NewStackLayout()
@@ -266,6 +701,35 @@ func NewFlexLayout() *layout.FlexLayout {
 	return layout.NewFlexLayout()
 }
 
+// NewGridLayout creates a new grid layout with the given number of columns.
+func NewGridLayout(columns int) *layout.GridLayout {
+	return layout.NewGridLayout(columns)
+}
+
+// NewAutoGridLayout creates a new grid layout fitting as many columns of at
+// least minColumnWidth pixels as the width allows.
+func NewAutoGridLayout(minColumnWidth int) *layout.GridLayout {
+	return layout.NewAutoGridLayout(minColumnWidth)
+}
+
+// NewBorderLayout creates a new border layout with components along its
+// edges, any of which may be nil, and the rest in the center.
+func NewBorderLayout(top, bottom, left, right shared.Component, center ...shared.Component) *layout.BorderLayout {
+	return layout.NewBorderLayout(top, bottom, left, right, center...)
+}
+
+// NewScrollContainer creates a new vertically scrolling container holding
+// the given components.
+func NewScrollContainer(content ...shared.Component) *layout.ScrollContainer {
+	return layout.NewScrollContainer(content...)
+}
+
+// NewSplitPane creates a new split pane with leading and trailing components
+// side by side or one above the other, and the divider in the middle.
+func NewSplitPane(direction Direction, leading, trailing shared.Component) *layout.SplitPane {
+	return layout.NewSplitPane(layout.Direction(direction), leading, trailing)
+}
+
 // SetTheme sets the current theme.
 func SetTheme(theme *themes.Theme) {
 	themes.SetTheme(theme)
@@ -290,3 +754,30 @@ func DarkTheme() *themes.Theme {
 func Alert(message string) {
 	ShowDialog("Alert", message, []string{"OK"})
 }
+
+// Confirm asks a yes/no question and reports whether it was confirmed.
+func Confirm(title, message string) bool {
+	if currentApp == nil {
+		fmt.Fprintln(os.Stderr, "Error: No active application for dialog")
+		return false
+	}
+	return currentApp.Confirm(title, message)
+}
+
+// ShowError displays an error dialog.
+func ShowError(err error) {
+	if currentApp == nil {
+		fmt.Fprintln(os.Stderr, "Error: No active application for dialog")
+		return
+	}
+	currentApp.ShowError(err)
+}
+
+// Prompt asks for a line of text. It returns the text and whether OK was clicked.
+func Prompt(title, message, placeholder string) (string, bool) {
+	if currentApp == nil {
+		fmt.Fprintln(os.Stderr, "Error: No active application for dialog")
+		return "", false
+	}
+	return currentApp.Prompt(title, message, placeholder)
+}
@@ -2,6 +2,9 @@ package components
 
 import (
 	"fmt"
+	"sync"
+
+	"gonic/shared"
 )
 
 // ButtonClickHandler is a function type for button click event handlers.
@@ -9,6 +12,8 @@ type ButtonClickHandler func()
 
 // Button represents a clickable button component.
 type Button struct {
+	shared.Notifier
+	mu       sync.RWMutex
 	text     string
 	onClick  ButtonClickHandler
 	disabled bool
@@ -35,44 +40,111 @@ func NewButton(text string, onClick ButtonClickHandler) *Button {
 
 // SetText sets the text of the button.
 func (b *Button) SetText(text string) {
+	b.mu.Lock()
 	b.text = text
+	b.mu.Unlock()
+	b.Notify(b)
 }
 
 // SetDisabled sets whether the button is disabled.
 func (b *Button) SetDisabled(disabled bool) {
+	b.mu.Lock()
 	b.disabled = disabled
+	b.mu.Unlock()
+	b.Notify(b)
 }
 
 // SetSize sets the size of the button.
 func (b *Button) SetSize(width, height int) {
+	b.mu.Lock()
 	b.width = width
 	b.height = height
+	b.mu.Unlock()
+	b.Notify(b)
 }
 
 // SetFontSize sets the font size of the button text.
 func (b *Button) SetFontSize(size int) {
+	b.mu.Lock()
 	b.fontSize = size
+	b.mu.Unlock()
+	b.Notify(b)
 }
 
 // SetColor sets the text color of the button.
 func (b *Button) SetColor(color string) {
+	b.mu.Lock()
 	b.color = color
+	b.mu.Unlock()
+	b.Notify(b)
 }
 
 // SetBackgroundColor sets the background color of the button.
 func (b *Button) SetBackgroundColor(color string) {
+	b.mu.Lock()
 	b.bgColor = color
+	b.mu.Unlock()
+	b.Notify(b)
+}
+
+// Text returns the text of the button.
+func (b *Button) Text() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.text
+}
+
+// Disabled reports whether the button is disabled.
+func (b *Button) Disabled() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.disabled
+}
+
+// Size returns the width and height of the button.
+func (b *Button) Size() (width, height int) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.width, b.height
+}
+
+// FontSize returns the font size of the button text.
+func (b *Button) FontSize() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.fontSize
+}
+
+// Color returns the text color of the button.
+func (b *Button) Color() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.color
+}
+
+// BackgroundColor returns the background color of the button.
+func (b *Button) BackgroundColor() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.bgColor
 }
 
 // Click simulates clicking the button, which triggers the onClick handler.
 func (b *Button) Click() {
-	if !b.disabled && b.onClick != nil {
-		b.onClick()
+	b.mu.RLock()
+	onClick, disabled := b.onClick, b.disabled
+	b.mu.RUnlock()
+
+	if !disabled && onClick != nil {
+		onClick()
 	}
 }
 
 // Render renders the button to a string.
 func (b *Button) Render() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	// In a real implementation, this would render the button using the backend
 	// For now, we'll just return a string representation
 	disabledStr := ""
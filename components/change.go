@@ -0,0 +1,19 @@
+package components
+
+import (
+	"gonic/shared"
+)
+
+// notifyingComponent is a component that notifies listeners of its changes.
+type notifyingComponent interface {
+	shared.Component
+	Notify(c shared.Component)
+}
+
+// rejectChange is called, without the component's lock held, when a
+// component ignores a change made by the user, e.g. because it is disabled.
+// Renderers already show what the user did, so listeners are notified to put
+// back the component's actual state.
+func rejectChange(c notifyingComponent) {
+	c.Notify(c)
+}
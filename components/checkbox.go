@@ -0,0 +1,112 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+
+	"gonic/shared"
+)
+
+// ToggleHandler is a function type for checkbox and switch change handlers.
+type ToggleHandler func(checked bool)
+
+// Checkbox represents a labelled box that can be checked and unchecked.
+type Checkbox struct {
+	shared.Notifier
+	mu       sync.RWMutex
+	label    string
+	checked  bool
+	disabled bool
+	onChange ToggleHandler
+}
+
+// NewCheckbox creates a new unchecked checkbox with the given label.
+func NewCheckbox(label string, onChange ToggleHandler) *Checkbox {
+	return &Checkbox{
+		label:    label,
+		onChange: onChange,
+	}
+}
+
+// SetLabel sets the text shown next to the checkbox.
+func (c *Checkbox) SetLabel(label string) {
+	c.mu.Lock()
+	c.label = label
+	c.mu.Unlock()
+	c.Notify(c)
+}
+
+// SetChecked sets whether the checkbox is checked. It does not trigger the OnChange handler.
+func (c *Checkbox) SetChecked(checked bool) {
+	c.mu.Lock()
+	c.checked = checked
+	c.mu.Unlock()
+	c.Notify(c)
+}
+
+// SetDisabled sets whether the checkbox is disabled.
+func (c *Checkbox) SetDisabled(disabled bool) {
+	c.mu.Lock()
+	c.disabled = disabled
+	c.mu.Unlock()
+	c.Notify(c)
+}
+
+// OnChange sets the handler called when the user checks or unchecks the checkbox.
+func (c *Checkbox) OnChange(handler ToggleHandler) {
+	c.mu.Lock()
+	c.onChange = handler
+	c.mu.Unlock()
+}
+
+// Label returns the text shown next to the checkbox.
+func (c *Checkbox) Label() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.label
+}
+
+// Checked reports whether the checkbox is checked.
+func (c *Checkbox) Checked() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.checked
+}
+
+// Disabled reports whether the checkbox is disabled.
+func (c *Checkbox) Disabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.disabled
+}
+
+// Change simulates the user checking or unchecking the checkbox, which
+// triggers the OnChange handler. Disabled checkboxes ignore the change.
+func (c *Checkbox) Change(checked bool) {
+	c.mu.Lock()
+	if c.disabled || c.checked == checked {
+		c.mu.Unlock()
+		rejectChange(c)
+		return
+	}
+	c.checked = checked
+	onChange := c.onChange
+	c.mu.Unlock()
+
+	c.Notify(c)
+	if onChange != nil {
+		onChange(checked)
+	}
+}
+
+// Render renders the checkbox to a string.
+func (c *Checkbox) Render() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	mark := " "
+	if c.checked {
+		mark = "x"
+	}
+	return fmt.Sprintf("[%s] %s", mark, c.label)
+}
@@ -0,0 +1,148 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+
+	"gonic/shared"
+)
+
+// ComboBox represents a text field that suggests options while still
+// accepting any text.
+type ComboBox struct {
+	shared.Notifier
+	mu          sync.RWMutex
+	text        string
+	options     []Option
+	placeholder string
+	disabled    bool
+	onChange    TextChangeHandler
+	onSelect    OptionHandler
+}
+
+// NewComboBox creates a new empty combo box suggesting the given options.
+func NewComboBox(options []Option, onSelect OptionHandler) *ComboBox {
+	return &ComboBox{
+		options:  append([]Option(nil), options...),
+		onSelect: onSelect,
+	}
+}
+
+// SetText sets the text of the combo box. It does not trigger any handler.
+func (c *ComboBox) SetText(text string) {
+	c.mu.Lock()
+	c.text = text
+	c.mu.Unlock()
+	c.Notify(c)
+}
+
+// SetOptions replaces the suggested options.
+func (c *ComboBox) SetOptions(options []Option) {
+	c.mu.Lock()
+	c.options = append([]Option(nil), options...)
+	c.mu.Unlock()
+	c.Notify(c)
+}
+
+// SetPlaceholder sets the text shown while the combo box is empty.
+func (c *ComboBox) SetPlaceholder(placeholder string) {
+	c.mu.Lock()
+	c.placeholder = placeholder
+	c.mu.Unlock()
+	c.Notify(c)
+}
+
+// SetDisabled sets whether the combo box is disabled.
+func (c *ComboBox) SetDisabled(disabled bool) {
+	c.mu.Lock()
+	c.disabled = disabled
+	c.mu.Unlock()
+	c.Notify(c)
+}
+
+// OnChange sets the handler called whenever the user changes the text.
+func (c *ComboBox) OnChange(handler TextChangeHandler) {
+	c.mu.Lock()
+	c.onChange = handler
+	c.mu.Unlock()
+}
+
+// OnSelect sets the handler called when the user picks one of the options.
+func (c *ComboBox) OnSelect(handler OptionHandler) {
+	c.mu.Lock()
+	c.onSelect = handler
+	c.mu.Unlock()
+}
+
+// Text returns the text of the combo box.
+func (c *ComboBox) Text() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.text
+}
+
+// Options returns the suggested options.
+func (c *ComboBox) Options() []Option {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Option(nil), c.options...)
+}
+
+// Placeholder returns the text shown while the combo box is empty.
+func (c *ComboBox) Placeholder() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.placeholder
+}
+
+// Disabled reports whether the combo box is disabled.
+func (c *ComboBox) Disabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.disabled
+}
+
+// Change simulates the user changing the text, which triggers the OnChange
+// handler. When the text matches the label of an option, the OnSelect handler
+// is triggered as well. Disabled combo boxes ignore the change.
+func (c *ComboBox) Change(text string) {
+	c.mu.Lock()
+	if c.disabled {
+		c.mu.Unlock()
+		rejectChange(c)
+		return
+	}
+	if c.text == text {
+		c.mu.Unlock()
+		return
+	}
+	c.text = text
+	onChange, onSelect := c.onChange, c.onSelect
+	option, picked := Option{}, false
+	for _, o := range c.options {
+		if o.Label == text {
+			option, picked = o, true
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	c.Notify(c)
+	if onChange != nil {
+		onChange(text)
+	}
+	if picked && onSelect != nil {
+		onSelect(option)
+	}
+}
+
+// Render renders the combo box to a string.
+func (c *ComboBox) Render() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.text == "" {
+		return fmt.Sprintf("[ComboBox: (%s)]", c.placeholder)
+	}
+	return fmt.Sprintf("[ComboBox: %s]", c.text)
+}
@@ -0,0 +1,49 @@
+package components
+
+import (
+	"gonic/layout"
+	"gonic/shared"
+)
+
+// headerSpacing is the space in pixels between the tab bar or navigation
+// bar of a container and the page below it.
+const headerSpacing = 8
+
+// header is the text of a tab bar or navigation bar, measured like a label.
+type header string
+
+// Render returns the text of the header.
+func (h header) Render() string {
+	return string(h)
+}
+
+// measureWithHeader returns the range of sizes of a page shown below a
+// header.
+func measureWithHeader(e *layout.Engine, text string, page shared.Component) layout.SizeHint {
+	top, content := e.Measure(header(text)), e.Measure(page)
+	stack := func(top, content layout.Size) layout.Size {
+		width := top.Width
+		if content.Width > width {
+			width = content.Width
+		}
+		return layout.Size{Width: width, Height: top.Height + headerSpacing + content.Height}
+	}
+	return layout.SizeHint{
+		Min:       stack(top.Min, content.Min),
+		Preferred: stack(top.Preferred, content.Preferred),
+	}
+}
+
+// arrangeWithHeader places a page below a header, across the width of
+// bounds. The header belongs to the container itself in hit tests.
+func arrangeWithHeader(e *layout.Engine, text string, page shared.Component, bounds layout.Rect) []*layout.Box {
+	if page == nil {
+		return nil
+	}
+	top := e.Measure(header(text)).Preferred.Height + headerSpacing
+	if top > bounds.Height {
+		top = bounds.Height
+	}
+	rect := layout.Rect{X: bounds.X, Y: bounds.Y + top, Width: bounds.Width, Height: bounds.Height - top}
+	return []*layout.Box{e.Arrange(page, rect)}
+}
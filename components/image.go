@@ -0,0 +1,218 @@
+package components
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	// Decoders for the formats images are usually stored in
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"gonic/shared"
+)
+
+// ImageFit sets how an image is scaled to the size of its component.
+type ImageFit int
+
+const (
+	// FitContain scales the image to fit inside the component, keeping its
+	// aspect ratio. Space around the image stays empty.
+	FitContain ImageFit = iota
+	// FitCover scales the image to cover the whole component, keeping its
+	// aspect ratio. Parts of the image may be cut off.
+	FitCover
+	// FitStretch scales the image to the size of the component, changing its
+	// aspect ratio if needed.
+	FitStretch
+)
+
+// Image represents a picture loaded from a file, a file system such as an
+// embed.FS, or an image.Image value.
+type Image struct {
+	shared.Notifier
+	mu      sync.RWMutex
+	file    string
+	fsys    fs.FS
+	img     image.Image
+	fit     ImageFit
+	width   int
+	height  int
+	alt     string
+	version int
+}
+
+// NewImageFromFile creates a new image loaded from a file on disk.
+func NewImageFromFile(file string) *Image {
+	return &Image{file: file}
+}
+
+// NewImageFromFS creates a new image loaded from a file in a file system,
+// such as an embed.FS.
+func NewImageFromFS(fsys fs.FS, name string) *Image {
+	return &Image{fsys: fsys, file: name}
+}
+
+// NewImageFromImage creates a new image showing an image.Image value.
+func NewImageFromImage(img image.Image) *Image {
+	return &Image{img: img}
+}
+
+// SetFile shows the image in a file on disk.
+func (i *Image) SetFile(file string) {
+	i.setSource(file, nil, nil)
+}
+
+// SetFS shows the image in a file of a file system, such as an embed.FS.
+func (i *Image) SetFS(fsys fs.FS, name string) {
+	i.setSource(name, fsys, nil)
+}
+
+// SetImage shows an image.Image value. Changes to the value are only shown
+// after calling SetImage again.
+func (i *Image) SetImage(img image.Image) {
+	i.setSource("", nil, img)
+}
+
+// setSource replaces where the image is loaded from.
+func (i *Image) setSource(file string, fsys fs.FS, img image.Image) {
+	i.mu.Lock()
+	i.file, i.fsys, i.img = file, fsys, img
+	i.version++
+	i.mu.Unlock()
+	i.Notify(i)
+}
+
+// SetFit sets how the image is scaled to the size of the component.
+func (i *Image) SetFit(fit ImageFit) {
+	i.mu.Lock()
+	i.fit = fit
+	i.mu.Unlock()
+	i.Notify(i)
+}
+
+// SetSize sets the size of the component in pixels. A size of zero uses the
+// intrinsic size of the image.
+func (i *Image) SetSize(width, height int) {
+	i.mu.Lock()
+	i.width = width
+	i.height = height
+	i.mu.Unlock()
+	i.Notify(i)
+}
+
+// SetAlt sets the text describing the image for screen readers and when it
+// can't be loaded.
+func (i *Image) SetAlt(alt string) {
+	i.mu.Lock()
+	i.alt = alt
+	i.mu.Unlock()
+	i.Notify(i)
+}
+
+// Fit returns how the image is scaled to the size of the component.
+func (i *Image) Fit() ImageFit {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.fit
+}
+
+// Size returns the size of the component in pixels, or zero to use the
+// intrinsic size of the image.
+func (i *Image) Size() (width, height int) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.width, i.height
+}
+
+// Alt returns the text describing the image.
+func (i *Image) Alt() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.alt
+}
+
+// Version returns a number that changes whenever the source of the image
+// changes, so that renderers caching the image know to load it again.
+func (i *Image) Version() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.version
+}
+
+// Data returns the encoded bytes of the image and their content type.
+// Images shown from an image.Image value are encoded as PNG.
+func (i *Image) Data() ([]byte, string, error) {
+	i.mu.RLock()
+	file, fsys, img := i.file, i.fsys, i.img
+	i.mu.RUnlock()
+
+	var data []byte
+	var err error
+	switch {
+	case img != nil:
+		var b bytes.Buffer
+		if err := png.Encode(&b, img); err != nil {
+			return nil, "", err
+		}
+		return b.Bytes(), "image/png", nil
+	case fsys != nil:
+		data, err = fs.ReadFile(fsys, file)
+	case file != "":
+		data, err = os.ReadFile(file)
+	default:
+		return nil, "", errors.New("image has no source")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(file))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return data, contentType, nil
+}
+
+// Decode returns the image as an image.Image value, decoding it if needed.
+// GIF, JPEG and PNG files can be decoded.
+func (i *Image) Decode() (image.Image, error) {
+	i.mu.RLock()
+	img := i.img
+	i.mu.RUnlock()
+	if img != nil {
+		return img, nil
+	}
+
+	data, _, err := i.Data()
+	if err != nil {
+		return nil, err
+	}
+	img, _, err = image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// Render renders the image to a string.
+func (i *Image) Render() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	name := filepath.Base(i.file)
+	if i.img != nil {
+		bounds := i.img.Bounds()
+		name = fmt.Sprintf("%dx%d image", bounds.Dx(), bounds.Dy())
+	}
+	if i.alt != "" {
+		name += ", " + i.alt
+	}
+	return fmt.Sprintf("[Image: %s]", name)
+}
@@ -3,10 +3,15 @@ package components
 
 import (
 	"fmt"
+	"sync"
+
+	"gonic/shared"
 )
 
 // Label represents a text label component.
 type Label struct {
+	shared.Notifier
+	mu       sync.RWMutex
 	text     string
 	fontSize int
 	bold     bool
@@ -21,37 +26,91 @@ func NewLabel(text string) *Label {
 		fontSize: 14, // Default font size
 		bold:     false,
 		italic:   false,
-		color:    "black", // Default color
 	}
 }
 
 // SetText sets the text of the label.
 func (l *Label) SetText(text string) {
+	l.mu.Lock()
 	l.text = text
+	l.mu.Unlock()
+	l.Notify(l)
 }
 
 // SetFontSize sets the font size of the label.
 func (l *Label) SetFontSize(size int) {
+	l.mu.Lock()
 	l.fontSize = size
+	l.mu.Unlock()
+	l.Notify(l)
 }
 
 // SetBold sets whether the label should be bold.
 func (l *Label) SetBold(bold bool) {
+	l.mu.Lock()
 	l.bold = bold
+	l.mu.Unlock()
+	l.Notify(l)
 }
 
 // SetItalic sets whether the label should be italic.
 func (l *Label) SetItalic(italic bool) {
+	l.mu.Lock()
 	l.italic = italic
+	l.mu.Unlock()
+	l.Notify(l)
 }
 
-// SetColor sets the color of the label.
+// SetColor sets the color of the label, such as "red" or "#336699". An empty
+// color uses the text color of the theme.
 func (l *Label) SetColor(color string) {
+	l.mu.Lock()
 	l.color = color
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// Text returns the text of the label.
+func (l *Label) Text() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.text
+}
+
+// FontSize returns the font size of the label.
+func (l *Label) FontSize() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.fontSize
+}
+
+// Bold reports whether the label is bold.
+func (l *Label) Bold() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.bold
+}
+
+// Italic reports whether the label is italic.
+func (l *Label) Italic() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.italic
+}
+
+// Color returns the color of the label, or an empty string if it uses the
+// text color of the theme.
+func (l *Label) Color() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.color
 }
 
 // Render renders the label to a string.
 func (l *Label) Render() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
 	// In a real implementation, this would render the label using the backend
 	// For now, we'll just return a string representation
 	var styleInfo string
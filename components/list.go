@@ -0,0 +1,255 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gonic/shared"
+)
+
+// ListItemFunc returns the text of the list item at the given index.
+type ListItemFunc func(index int) string
+
+// ListSelectHandler is a function type for list selection handlers.
+type ListSelectHandler func(indexes []int)
+
+// List represents a scrolling list of text items. Items are built on demand
+// by a callback, so only the visible items are ever materialized and lists
+// can hold hundreds of thousands of entries.
+type List struct {
+	shared.Notifier
+	mu            sync.RWMutex
+	length        int
+	item          ListItemFunc
+	itemHeight    int
+	height        int
+	followTail    bool
+	selectionMode SelectionMode
+	selected      []int
+	onSelect      ListSelectHandler
+}
+
+// NewList creates a new list with the given number of items, built by item.
+func NewList(length int, item ListItemFunc) *List {
+	return &List{
+		length:     length,
+		item:       item,
+		itemHeight: 24,  // Default item height
+		height:     300, // Default height
+	}
+}
+
+// SetLength sets the number of items. Selected items past the end are unselected.
+func (l *List) SetLength(length int) {
+	if length < 0 {
+		length = 0
+	}
+
+	l.mu.Lock()
+	l.length = length
+	selected := l.selected[:0]
+	for _, index := range l.selected {
+		if index < length {
+			selected = append(selected, index)
+		}
+	}
+	l.selected = selected
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// Append adds count items at the end of the list, for example when new log
+// lines arrive. It is safe to call from any goroutine.
+func (l *List) Append(count int) {
+	if count <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	l.length += count
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// SetItemFunc replaces the callback that builds the items.
+func (l *List) SetItemFunc(item ListItemFunc) {
+	l.mu.Lock()
+	l.item = item
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// SetItemHeight sets the height of every item in pixels.
+func (l *List) SetItemHeight(height int) {
+	l.mu.Lock()
+	l.itemHeight = height
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// SetHeight sets the height of the visible part of the list in pixels.
+func (l *List) SetHeight(height int) {
+	l.mu.Lock()
+	l.height = height
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// SetFollowTail sets whether the list keeps scrolling to new items appended
+// at the end while it is scrolled to the bottom.
+func (l *List) SetFollowTail(follow bool) {
+	l.mu.Lock()
+	l.followTail = follow
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// SetSelectionMode sets how many items the user can select. Changing the mode clears the selection.
+func (l *List) SetSelectionMode(mode SelectionMode) {
+	l.mu.Lock()
+	l.selectionMode = mode
+	l.selected = nil
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// SetSelected selects the items at the given indexes. It does not trigger the OnSelect handler.
+func (l *List) SetSelected(indexes []int) {
+	l.mu.Lock()
+	l.selected = nil
+	for _, index := range indexes {
+		if index >= 0 && index < l.length && l.selectedIndex(index) < 0 {
+			l.selected = append(l.selected, index)
+		}
+	}
+	l.mu.Unlock()
+	l.Notify(l)
+}
+
+// OnSelect sets the handler called when the user changes the selection.
+func (l *List) OnSelect(handler ListSelectHandler) {
+	l.mu.Lock()
+	l.onSelect = handler
+	l.mu.Unlock()
+}
+
+// Length returns the number of items.
+func (l *List) Length() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.length
+}
+
+// Item returns the text of the item at the given index, or an empty string if there is none.
+func (l *List) Item(index int) string {
+	l.mu.RLock()
+	item, length := l.item, l.length
+	l.mu.RUnlock()
+
+	// The callback is called without holding the lock, it may be slow
+	if item == nil || index < 0 || index >= length {
+		return ""
+	}
+	return item(index)
+}
+
+// ItemHeight returns the height of every item in pixels.
+func (l *List) ItemHeight() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.itemHeight
+}
+
+// Height returns the height of the visible part of the list in pixels.
+func (l *List) Height() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.height
+}
+
+// FollowTail reports whether the list keeps scrolling to new items.
+func (l *List) FollowTail() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.followTail
+}
+
+// SelectionMode returns how many items the user can select.
+func (l *List) SelectionMode() SelectionMode {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.selectionMode
+}
+
+// Selected returns the indexes of the selected items in ascending order.
+func (l *List) Selected() []int {
+	l.mu.RLock()
+	selected := append([]int(nil), l.selected...)
+	l.mu.RUnlock()
+
+	sort.Ints(selected)
+	return selected
+}
+
+// IsSelected reports whether the item at the given index is selected.
+func (l *List) IsSelected(index int) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.selectedIndex(index) >= 0
+}
+
+// Select simulates the user clicking the item at the given index, which
+// triggers the OnSelect handler. With single selection the item replaces the
+// selection, with multi selection it is added to or removed from it.
+func (l *List) Select(index int) {
+	l.mu.Lock()
+	if l.selectionMode == NoSelection || index < 0 || index >= l.length {
+		l.mu.Unlock()
+		return
+	}
+	if l.selectionMode == SingleSelection {
+		l.selected = []int{index}
+	} else if i := l.selectedIndex(index); i >= 0 {
+		l.selected = append(l.selected[:i:i], l.selected[i+1:]...)
+	} else {
+		l.selected = append(l.selected, index)
+	}
+	onSelect := l.onSelect
+	l.mu.Unlock()
+
+	l.Notify(l)
+	if onSelect != nil {
+		onSelect(l.Selected())
+	}
+}
+
+// selectedIndex returns the position of an item in the selection, or -1.
+// The caller must hold the lock.
+func (l *List) selectedIndex(index int) int {
+	for i, selected := range l.selected {
+		if selected == index {
+			return i
+		}
+	}
+	return -1
+}
+
+// Render renders the list to a string. Only the first items are included.
+func (l *List) Render() string {
+	length := l.Length()
+	shown := length
+	if shown > 10 {
+		shown = 10
+	}
+
+	lines := make([]string, 0, shown+1)
+	for i := 0; i < shown; i++ {
+		lines = append(lines, l.Item(i))
+	}
+	if length > shown {
+		lines = append(lines, fmt.Sprintf("... %d more", length-shown))
+	}
+	return strings.Join(lines, "\n")
+}
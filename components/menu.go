@@ -0,0 +1,348 @@
+package components
+
+import (
+	"strings"
+	"sync"
+
+	"gonic/internal"
+	"gonic/shared"
+)
+
+// Shortcut is a key combination that triggers a menu item, such as Ctrl+S.
+type Shortcut struct {
+	// Key is the key to press.
+	Key internal.KeyCode
+	// Modifiers are the modifier keys held down with it.
+	Modifiers internal.KeyModifiers
+}
+
+// IsZero reports whether the shortcut is unset.
+func (s Shortcut) IsZero() bool {
+	return s.Key == 0
+}
+
+// String returns the shortcut as shown in menus, such as "Ctrl+Shift+S".
+// Modifiers are listed in the order Ctrl, Alt, Shift, Super.
+func (s Shortcut) String() string {
+	if s.IsZero() {
+		return ""
+	}
+
+	var parts []string
+	if s.Modifiers&internal.ModCtrl != 0 {
+		parts = append(parts, "Ctrl")
+	}
+	if s.Modifiers&internal.ModAlt != 0 {
+		parts = append(parts, "Alt")
+	}
+	if s.Modifiers&internal.ModShift != 0 {
+		parts = append(parts, "Shift")
+	}
+	if s.Modifiers&internal.ModSuper != 0 {
+		parts = append(parts, "Super")
+	}
+	return strings.Join(append(parts, keyName(s.Key)), "+")
+}
+
+// keyName returns the name of a key as shown in menus.
+func keyName(key internal.KeyCode) string {
+	switch key {
+	case internal.KeyEscape:
+		return "Esc"
+	case internal.KeyEnter:
+		return "Enter"
+	case internal.KeySpace:
+		return "Space"
+	case internal.KeyBackspace:
+		return "Backspace"
+	case internal.KeyTab:
+		return "Tab"
+	}
+	if key >= internal.KeyA && key <= internal.KeyZ {
+		return strings.ToUpper(string(rune(key)))
+	}
+	return "?"
+}
+
+// MenuItem represents an entry of a Menu. An item either runs an action,
+// toggles a check mark, opens a submenu or separates groups of items.
+type MenuItem struct {
+	shared.Notifier
+	mu        sync.RWMutex
+	label     string
+	shortcut  Shortcut
+	checkable bool
+	checked   bool
+	disabled  bool
+	separator bool
+	submenu   *Menu
+	action    ButtonClickHandler
+	onToggle  ToggleHandler
+}
+
+// NewMenuItem creates a new menu item that calls action when clicked.
+func NewMenuItem(label string, action ButtonClickHandler) *MenuItem {
+	return &MenuItem{
+		label:  label,
+		action: action,
+	}
+}
+
+// NewCheckMenuItem creates a new unchecked menu item that toggles its check
+// mark when clicked and calls onToggle with the new state.
+func NewCheckMenuItem(label string, onToggle ToggleHandler) *MenuItem {
+	return &MenuItem{
+		label:     label,
+		checkable: true,
+		onToggle:  onToggle,
+	}
+}
+
+// NewSubmenuItem creates a new menu item that opens a submenu.
+func NewSubmenuItem(label string, submenu *Menu) *MenuItem {
+	return &MenuItem{
+		label:   label,
+		submenu: submenu,
+	}
+}
+
+// NewMenuSeparator creates a new line separating groups of menu items.
+func NewMenuSeparator() *MenuItem {
+	return &MenuItem{
+		separator: true,
+	}
+}
+
+// SetLabel sets the text of the item.
+func (m *MenuItem) SetLabel(label string) {
+	m.mu.Lock()
+	m.label = label
+	m.mu.Unlock()
+	m.Notify(m)
+}
+
+// SetShortcut sets the key combination that triggers the item.
+func (m *MenuItem) SetShortcut(shortcut Shortcut) {
+	m.mu.Lock()
+	m.shortcut = shortcut
+	m.mu.Unlock()
+	m.Notify(m)
+}
+
+// SetChecked sets whether a checkable item is checked. It does not trigger the OnToggle handler.
+func (m *MenuItem) SetChecked(checked bool) {
+	m.mu.Lock()
+	m.checked = checked && m.checkable
+	m.mu.Unlock()
+	m.Notify(m)
+}
+
+// SetDisabled sets whether the item is disabled.
+func (m *MenuItem) SetDisabled(disabled bool) {
+	m.mu.Lock()
+	m.disabled = disabled
+	m.mu.Unlock()
+	m.Notify(m)
+}
+
+// OnClick sets the handler called when the user clicks the item.
+func (m *MenuItem) OnClick(handler ButtonClickHandler) {
+	m.mu.Lock()
+	m.action = handler
+	m.mu.Unlock()
+}
+
+// OnToggle sets the handler called when the user checks or unchecks a checkable item.
+func (m *MenuItem) OnToggle(handler ToggleHandler) {
+	m.mu.Lock()
+	m.onToggle = handler
+	m.mu.Unlock()
+}
+
+// Label returns the text of the item.
+func (m *MenuItem) Label() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.label
+}
+
+// Shortcut returns the key combination that triggers the item.
+func (m *MenuItem) Shortcut() Shortcut {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.shortcut
+}
+
+// Checkable reports whether the item toggles a check mark.
+func (m *MenuItem) Checkable() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.checkable
+}
+
+// Checked reports whether the item is checked.
+func (m *MenuItem) Checked() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.checked
+}
+
+// Disabled reports whether the item is disabled.
+func (m *MenuItem) Disabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.disabled
+}
+
+// IsSeparator reports whether the item is a separator.
+func (m *MenuItem) IsSeparator() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.separator
+}
+
+// Submenu returns the submenu opened by the item, or nil if there is none.
+func (m *MenuItem) Submenu() *Menu {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.submenu
+}
+
+// Click simulates the user clicking the item, which toggles checkable items
+// and triggers their handlers. Disabled items, separators and submenus ignore
+// the click.
+func (m *MenuItem) Click() {
+	m.mu.Lock()
+	if m.disabled || m.separator || m.submenu != nil {
+		m.mu.Unlock()
+		return
+	}
+	if m.checkable {
+		m.checked = !m.checked
+	}
+	checkable, checked := m.checkable, m.checked
+	action, onToggle := m.action, m.onToggle
+	m.mu.Unlock()
+
+	if checkable {
+		m.Notify(m)
+		if onToggle != nil {
+			onToggle(checked)
+		}
+	}
+	if action != nil {
+		action()
+	}
+}
+
+// Render renders the menu item to a string.
+func (m *MenuItem) Render() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.separator {
+		return "---"
+	}
+	text := m.label
+	if m.checkable {
+		if m.checked {
+			text = "[x] " + text
+		} else {
+			text = "[ ] " + text
+		}
+	}
+	if m.submenu != nil {
+		text += " >"
+	}
+	if !m.shortcut.IsZero() {
+		text += " (" + m.shortcut.String() + ")"
+	}
+	if m.disabled {
+		text += " (disabled)"
+	}
+	return text
+}
+
+// Menu represents a list of menu items, shown in a window's menu bar, as a
+// submenu or as the context menu of a component.
+type Menu struct {
+	shared.Notifier
+	mu    sync.RWMutex
+	label string
+	items []*MenuItem
+}
+
+// NewMenu creates a new menu with the given label and items.
+func NewMenu(label string, items ...*MenuItem) *Menu {
+	return &Menu{
+		label: label,
+		items: append([]*MenuItem(nil), items...),
+	}
+}
+
+// SetLabel sets the text shown for the menu in the menu bar.
+func (m *Menu) SetLabel(label string) {
+	m.mu.Lock()
+	m.label = label
+	m.mu.Unlock()
+	m.Notify(m)
+}
+
+// Add adds items at the end of the menu.
+func (m *Menu) Add(items ...*MenuItem) {
+	m.mu.Lock()
+	m.items = append(m.items, items...)
+	m.mu.Unlock()
+	m.Notify(m)
+}
+
+// Label returns the text shown for the menu in the menu bar.
+func (m *Menu) Label() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.label
+}
+
+// Items returns the items of the menu.
+func (m *Menu) Items() []*MenuItem {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]*MenuItem(nil), m.items...)
+}
+
+// FindShortcut returns the enabled item triggered by a shortcut, searching
+// submenus too, or nil if there is none.
+func (m *Menu) FindShortcut(shortcut Shortcut) *MenuItem {
+	if shortcut.IsZero() {
+		return nil
+	}
+	for _, item := range m.Items() {
+		if submenu := item.Submenu(); submenu != nil {
+			if found := submenu.FindShortcut(shortcut); found != nil {
+				return found
+			}
+		} else if item.Shortcut() == shortcut && !item.Disabled() {
+			return item
+		}
+	}
+	return nil
+}
+
+// Render renders the menu to a string.
+func (m *Menu) Render() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lines := []string{m.label}
+	for _, item := range m.items {
+		for _, line := range strings.Split(item.Render(), "\n") {
+			lines = append(lines, "  "+line)
+		}
+		if submenu := item.Submenu(); submenu != nil {
+			for _, line := range strings.Split(submenu.Render(), "\n")[1:] {
+				lines = append(lines, "  "+line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,170 @@
+package components
+
+import (
+	"strings"
+	"sync"
+
+	"gonic/layout"
+	"gonic/shared"
+)
+
+// Page is a page of a Navigator.
+type Page struct {
+	// Title is shown above the page.
+	Title string
+	// Content is the layout shown while the page is on top.
+	Content shared.Layout
+}
+
+// PageChangeHandler is a function type for navigator page change handlers.
+type PageChangeHandler func(page Page)
+
+// Navigator represents a stack of pages where only the top page is shown.
+// Pages are pushed to drill down into details, and a back button returns
+// to the previous page.
+type Navigator struct {
+	shared.Notifier
+	mu     sync.RWMutex
+	pages  []Page
+	onBack PageChangeHandler
+}
+
+// NewNavigator creates a new navigator showing the given root page.
+func NewNavigator(title string, root shared.Layout) *Navigator {
+	return &Navigator{
+		pages: []Page{{Title: title, Content: root}},
+	}
+}
+
+// Push shows a new page on top of the current one.
+func (n *Navigator) Push(title string, content shared.Layout) {
+	n.mu.Lock()
+	n.pages = append(n.pages, Page{Title: title, Content: content})
+	n.mu.Unlock()
+	n.Notify(n)
+}
+
+// Pop removes the top page and returns it. The root page is never removed.
+// It does not trigger the OnBack handler.
+func (n *Navigator) Pop() (Page, bool) {
+	n.mu.Lock()
+	if len(n.pages) <= 1 {
+		n.mu.Unlock()
+		return Page{}, false
+	}
+	page := n.pages[len(n.pages)-1]
+	n.pages = n.pages[:len(n.pages)-1]
+	n.mu.Unlock()
+	n.Notify(n)
+	return page, true
+}
+
+// PopToRoot removes every page except the root page.
+func (n *Navigator) PopToRoot() {
+	n.mu.Lock()
+	n.pages = n.pages[:1]
+	n.mu.Unlock()
+	n.Notify(n)
+}
+
+// OnBack sets the handler called with the page shown after the user goes back.
+func (n *Navigator) OnBack(handler PageChangeHandler) {
+	n.mu.Lock()
+	n.onBack = handler
+	n.mu.Unlock()
+}
+
+// Pages returns the pages from the root page to the top page.
+func (n *Navigator) Pages() []Page {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return append([]Page(nil), n.pages...)
+}
+
+// Current returns the top page.
+func (n *Navigator) Current() Page {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.pages[len(n.pages)-1]
+}
+
+// Depth returns the number of pages on the stack.
+func (n *Navigator) Depth() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.pages)
+}
+
+// CanGoBack reports whether there is a page to go back to.
+func (n *Navigator) CanGoBack() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.pages) > 1
+}
+
+// Back simulates the user clicking the back button, which removes the top
+// page and triggers the OnBack handler.
+func (n *Navigator) Back() {
+	n.mu.Lock()
+	if len(n.pages) <= 1 {
+		n.mu.Unlock()
+		return
+	}
+	n.pages = n.pages[:len(n.pages)-1]
+	page := n.pages[len(n.pages)-1]
+	onBack := n.onBack
+	n.mu.Unlock()
+
+	n.Notify(n)
+	if onBack != nil {
+		onBack(page)
+	}
+}
+
+// bar returns the text of the navigation bar, with the back button and the
+// title of the top page, and the content of the top page, or nil if it has
+// none.
+func (n *Navigator) bar() (string, shared.Component) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	top := n.pages[len(n.pages)-1]
+	text := top.Title
+	if len(n.pages) > 1 {
+		text = "‹ " + n.pages[len(n.pages)-2].Title + "  " + text
+	}
+	if top.Content == nil {
+		return text, nil
+	}
+	return text, top.Content
+}
+
+// MeasureLayout returns the range of sizes of the navigation bar above the
+// top page.
+func (n *Navigator) MeasureLayout(e *layout.Engine) layout.SizeHint {
+	text, page := n.bar()
+	return measureWithHeader(e, text, page)
+}
+
+// ArrangeLayout places the top page below the navigation bar. The pages
+// below it aren't shown, so they get no box.
+func (n *Navigator) ArrangeLayout(e *layout.Engine, bounds layout.Rect) []*layout.Box {
+	text, page := n.bar()
+	return arrangeWithHeader(e, text, page, bounds)
+}
+
+// Render renders the titles of the pages and the top page to a string.
+func (n *Navigator) Render() string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	titles := make([]string, len(n.pages))
+	for i, page := range n.pages {
+		titles[i] = page.Title
+	}
+	lines := []string{strings.Join(titles, " > ")}
+	if top := n.pages[len(n.pages)-1]; top.Content != nil {
+		lines = append(lines, top.Content.Render())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,81 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gonic/shared"
+)
+
+// ProgressBar represents a bar showing how far a task has progressed.
+type ProgressBar struct {
+	shared.Notifier
+	mu            sync.RWMutex
+	value         float64
+	indeterminate bool
+}
+
+// NewProgressBar creates a new progress bar at zero. Its value ranges from 0 to 1.
+func NewProgressBar() *ProgressBar {
+	return &ProgressBar{}
+}
+
+// NewIndeterminateProgressBar creates a new progress bar for tasks of unknown length.
+func NewIndeterminateProgressBar() *ProgressBar {
+	return &ProgressBar{
+		indeterminate: true,
+	}
+}
+
+// SetValue sets the progress, from 0 to 1. It is safe to call from any goroutine.
+func (p *ProgressBar) SetValue(value float64) {
+	if value < 0 {
+		value = 0
+	} else if value > 1 {
+		value = 1
+	}
+
+	p.mu.Lock()
+	if p.value == value {
+		p.mu.Unlock()
+		return
+	}
+	p.value = value
+	p.mu.Unlock()
+	p.Notify(p)
+}
+
+// SetIndeterminate sets whether the progress bar shows activity instead of a value.
+func (p *ProgressBar) SetIndeterminate(indeterminate bool) {
+	p.mu.Lock()
+	p.indeterminate = indeterminate
+	p.mu.Unlock()
+	p.Notify(p)
+}
+
+// Value returns the progress, from 0 to 1.
+func (p *ProgressBar) Value() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.value
+}
+
+// Indeterminate reports whether the progress bar shows activity instead of a value.
+func (p *ProgressBar) Indeterminate() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.indeterminate
+}
+
+// Render renders the progress bar to a string.
+func (p *ProgressBar) Render() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.indeterminate {
+		return "[Progress: ...]"
+	}
+	filled := int(p.value * 20)
+	return fmt.Sprintf("[%s%s] %.0f%%", strings.Repeat("#", filled), strings.Repeat("-", 20-filled), p.value*100)
+}
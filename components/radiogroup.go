@@ -0,0 +1,136 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gonic/shared"
+)
+
+// SelectHandler is a function type for handlers called when an option is chosen.
+type SelectHandler func(index int, option string)
+
+// RadioGroup represents a list of options of which at most one is selected.
+type RadioGroup struct {
+	shared.Notifier
+	mu       sync.RWMutex
+	options  []string
+	selected int
+	disabled bool
+	onChange SelectHandler
+}
+
+// NewRadioGroup creates a new radio group with the given options and nothing selected.
+func NewRadioGroup(options []string, onChange SelectHandler) *RadioGroup {
+	return &RadioGroup{
+		options:  append([]string(nil), options...),
+		selected: -1,
+		onChange: onChange,
+	}
+}
+
+// SetOptions replaces the options. The selection is cleared if it no longer exists.
+func (g *RadioGroup) SetOptions(options []string) {
+	g.mu.Lock()
+	g.options = append([]string(nil), options...)
+	if g.selected >= len(g.options) {
+		g.selected = -1
+	}
+	g.mu.Unlock()
+	g.Notify(g)
+}
+
+// SetSelected selects the option at the given index, or clears the selection
+// if the index is out of range. It does not trigger the OnChange handler.
+func (g *RadioGroup) SetSelected(index int) {
+	g.mu.Lock()
+	if index < 0 || index >= len(g.options) {
+		index = -1
+	}
+	g.selected = index
+	g.mu.Unlock()
+	g.Notify(g)
+}
+
+// SetDisabled sets whether the radio group is disabled.
+func (g *RadioGroup) SetDisabled(disabled bool) {
+	g.mu.Lock()
+	g.disabled = disabled
+	g.mu.Unlock()
+	g.Notify(g)
+}
+
+// OnChange sets the handler called when the user selects an option.
+func (g *RadioGroup) OnChange(handler SelectHandler) {
+	g.mu.Lock()
+	g.onChange = handler
+	g.mu.Unlock()
+}
+
+// Options returns the options of the radio group.
+func (g *RadioGroup) Options() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]string(nil), g.options...)
+}
+
+// Selected returns the index of the selected option, or -1 if nothing is selected.
+func (g *RadioGroup) Selected() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.selected
+}
+
+// SelectedOption returns the selected option, or an empty string if nothing is selected.
+func (g *RadioGroup) SelectedOption() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.selected < 0 {
+		return ""
+	}
+	return g.options[g.selected]
+}
+
+// Disabled reports whether the radio group is disabled.
+func (g *RadioGroup) Disabled() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.disabled
+}
+
+// Select simulates the user selecting the option at the given index, which
+// triggers the OnChange handler. Disabled groups and unknown options are ignored.
+func (g *RadioGroup) Select(index int) {
+	g.mu.Lock()
+	if g.disabled || index < 0 || index >= len(g.options) || index == g.selected {
+		g.mu.Unlock()
+		rejectChange(g)
+		return
+	}
+	g.selected = index
+	option := g.options[index]
+	onChange := g.onChange
+	g.mu.Unlock()
+
+	g.Notify(g)
+	if onChange != nil {
+		onChange(index, option)
+	}
+}
+
+// Render renders the radio group to a string.
+func (g *RadioGroup) Render() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	lines := make([]string, len(g.options))
+	for i, option := range g.options {
+		mark := " "
+		if i == g.selected {
+			mark = "*"
+		}
+		lines[i] = fmt.Sprintf("(%s) %s", mark, option)
+	}
+	return strings.Join(lines, "\n")
+}
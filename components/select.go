@@ -0,0 +1,168 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+
+	"gonic/shared"
+)
+
+// Option is a choice offered by a Select or ComboBox. The label is shown to
+// the user, the value identifies the option in code.
+type Option struct {
+	Label string
+	Value string
+}
+
+// OptionHandler is a function type for handlers called when an option is picked.
+type OptionHandler func(option Option)
+
+// Select represents a dropdown list of fixed options with a single selection.
+type Select struct {
+	shared.Notifier
+	mu          sync.RWMutex
+	options     []Option
+	selected    int
+	placeholder string
+	disabled    bool
+	onSelect    OptionHandler
+}
+
+// NewSelect creates a new select with the given options and nothing selected.
+func NewSelect(options []Option, onSelect OptionHandler) *Select {
+	return &Select{
+		options:  append([]Option(nil), options...),
+		selected: -1,
+		onSelect: onSelect,
+	}
+}
+
+// SetOptions replaces the options. The selected option is kept if one with the
+// same value still exists, otherwise the selection is cleared.
+func (s *Select) SetOptions(options []Option) {
+	s.mu.Lock()
+	previous := s.selected
+	value := ""
+	if previous >= 0 {
+		value = s.options[previous].Value
+	}
+	s.options = append([]Option(nil), options...)
+	s.selected = -1
+	if previous >= 0 {
+		s.selected = s.indexOf(value)
+	}
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetSelected selects the option with the given value, or clears the selection
+// if there is none. It does not trigger the OnSelect handler.
+func (s *Select) SetSelected(value string) {
+	s.mu.Lock()
+	s.selected = s.indexOf(value)
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetPlaceholder sets the text shown while nothing is selected.
+func (s *Select) SetPlaceholder(placeholder string) {
+	s.mu.Lock()
+	s.placeholder = placeholder
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetDisabled sets whether the select is disabled.
+func (s *Select) SetDisabled(disabled bool) {
+	s.mu.Lock()
+	s.disabled = disabled
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// OnSelect sets the handler called when the user selects an option.
+func (s *Select) OnSelect(handler OptionHandler) {
+	s.mu.Lock()
+	s.onSelect = handler
+	s.mu.Unlock()
+}
+
+// Options returns the options of the select.
+func (s *Select) Options() []Option {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Option(nil), s.options...)
+}
+
+// Selected returns the selected option and whether an option is selected.
+func (s *Select) Selected() (Option, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.selected < 0 {
+		return Option{}, false
+	}
+	return s.options[s.selected], true
+}
+
+// Value returns the value of the selected option, or an empty string if nothing is selected.
+func (s *Select) Value() string {
+	option, _ := s.Selected()
+	return option.Value
+}
+
+// Placeholder returns the text shown while nothing is selected.
+func (s *Select) Placeholder() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.placeholder
+}
+
+// Disabled reports whether the select is disabled.
+func (s *Select) Disabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.disabled
+}
+
+// Select simulates the user selecting the option with the given value, which
+// triggers the OnSelect handler. Disabled selects and unknown values are ignored.
+func (s *Select) Select(value string) {
+	s.mu.Lock()
+	index := s.indexOf(value)
+	if s.disabled || index < 0 || index == s.selected {
+		s.mu.Unlock()
+		rejectChange(s)
+		return
+	}
+	s.selected = index
+	option := s.options[index]
+	onSelect := s.onSelect
+	s.mu.Unlock()
+
+	s.Notify(s)
+	if onSelect != nil {
+		onSelect(option)
+	}
+}
+
+// indexOf returns the index of the option with the given value, or -1.
+// The caller must hold the lock.
+func (s *Select) indexOf(value string) int {
+	for i, option := range s.options {
+		if option.Value == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// Render renders the select to a string.
+func (s *Select) Render() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.selected < 0 {
+		return fmt.Sprintf("[Select: (%s)]", s.placeholder)
+	}
+	return fmt.Sprintf("[Select: %s]", s.options[s.selected].Label)
+}
@@ -0,0 +1,22 @@
+package components
+
+import "testing"
+
+func TestSetOptionsKeepsSelection(t *testing.T) {
+	s := NewSelect([]Option{{"None", ""}, {"Red", "red"}}, nil)
+	s.SetSelected("")
+	s.SetOptions([]Option{{"Blue", "blue"}, {"None", ""}})
+	if option, ok := s.Selected(); !ok || option.Label != "None" {
+		t.Fatalf("Selected() = %v, %v after new options, want the option with an empty value", option, ok)
+	}
+
+	s.SetSelected("blue")
+	s.SetOptions([]Option{{"None", ""}})
+	if option, ok := s.Selected(); ok {
+		t.Fatalf("Selected() = %v after the selected option was removed, want nothing", option)
+	}
+	s.SetOptions([]Option{{"None", ""}, {"Blue", "blue"}})
+	if option, ok := s.Selected(); ok {
+		t.Fatalf("Selected() = %v after new options, want nothing selected to stay unselected", option)
+	}
+}
@@ -0,0 +1,164 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"gonic/shared"
+)
+
+// SliderChangeHandler is a function type for slider change handlers.
+type SliderChangeHandler func(value float64)
+
+// Slider represents a control for picking a number from a range.
+type Slider struct {
+	shared.Notifier
+	mu       sync.RWMutex
+	min      float64
+	max      float64
+	step     float64
+	value    float64
+	disabled bool
+	onChange SliderChangeHandler
+}
+
+// NewSlider creates a new slider for values from min to max, starting at min.
+// The slider moves continuously until a step is set.
+func NewSlider(min, max float64, onChange SliderChangeHandler) *Slider {
+	if max < min {
+		min, max = max, min
+	}
+	return &Slider{
+		min:      min,
+		max:      max,
+		value:    min,
+		onChange: onChange,
+	}
+}
+
+// SetRange sets the lowest and highest values of the slider.
+func (s *Slider) SetRange(min, max float64) {
+	if max < min {
+		min, max = max, min
+	}
+
+	s.mu.Lock()
+	s.min = min
+	s.max = max
+	s.value = s.snap(s.value)
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetStep sets the increment between values. Zero allows any value.
+func (s *Slider) SetStep(step float64) {
+	s.mu.Lock()
+	s.step = math.Abs(step)
+	s.value = s.snap(s.value)
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetValue sets the value of the slider. It does not trigger the OnChange handler.
+func (s *Slider) SetValue(value float64) {
+	s.mu.Lock()
+	s.value = s.snap(value)
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetDisabled sets whether the slider is disabled.
+func (s *Slider) SetDisabled(disabled bool) {
+	s.mu.Lock()
+	s.disabled = disabled
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// OnChange sets the handler called when the user moves the slider.
+func (s *Slider) OnChange(handler SliderChangeHandler) {
+	s.mu.Lock()
+	s.onChange = handler
+	s.mu.Unlock()
+}
+
+// Range returns the lowest and highest values of the slider.
+func (s *Slider) Range() (min, max float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.min, s.max
+}
+
+// Step returns the increment between values, or zero if any value is allowed.
+func (s *Slider) Step() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.step
+}
+
+// Value returns the value of the slider.
+func (s *Slider) Value() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.value
+}
+
+// Disabled reports whether the slider is disabled.
+func (s *Slider) Disabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.disabled
+}
+
+// Change simulates the user moving the slider, which triggers the OnChange
+// handler. The value is kept in range and rounded to the nearest step, if
+// one is set.
+// Disabled sliders ignore the change.
+func (s *Slider) Change(value float64) {
+	s.mu.Lock()
+	if s.disabled {
+		s.mu.Unlock()
+		rejectChange(s)
+		return
+	}
+	snapped := s.snap(value)
+	if snapped == s.value {
+		s.mu.Unlock()
+		if snapped != value {
+			// Move the displayed slider onto the step
+			s.Notify(s)
+		}
+		return
+	}
+	s.value = snapped
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	s.Notify(s)
+	if onChange != nil {
+		onChange(snapped)
+	}
+}
+
+// snap keeps a value in range and rounds it to the nearest step, if one is
+// set. Values past the last step are moved back onto it.
+// The caller must hold the lock.
+func (s *Slider) snap(value float64) float64 {
+	if s.step > 0 {
+		value = s.min + math.Round((value-s.min)/s.step)*s.step
+		if value > s.max {
+			// Allow for rounding errors when max is on a step
+			value = s.min + math.Floor((s.max-s.min)/s.step+1e-9)*s.step
+		}
+	}
+	return math.Max(s.min, math.Min(s.max, value))
+}
+
+// Render renders the slider to a string.
+func (s *Slider) Render() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fmt.Sprintf("[Slider: %g (%g-%g)]", s.value, s.min, s.max)
+}
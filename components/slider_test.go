@@ -0,0 +1,32 @@
+package components
+
+import "testing"
+
+func TestSliderSteps(t *testing.T) {
+	var changes []float64
+	s := NewSlider(0, 1, func(value float64) {
+		changes = append(changes, value)
+	})
+	if s.Step() != 0 {
+		t.Fatalf("Step() = %g for a new slider, want 0", s.Step())
+	}
+
+	// Without a step any value in range is kept
+	s.Change(0.35)
+	s.Change(1.5)
+	if s.Value() != 1 || len(changes) != 2 || changes[0] != 0.35 {
+		t.Fatalf("continuous slider is at %g after changes %v, want 1 after 0.35", s.Value(), changes)
+	}
+
+	// With a step values are rounded to it, counting from the lowest value
+	s.SetRange(0.1, 2)
+	s.SetStep(0.5)
+	s.SetValue(0.7)
+	if s.Value() != 0.6 {
+		t.Fatalf("Value() = %g, want 0.6", s.Value())
+	}
+	s.Change(2)
+	if s.Value() != 1.6 {
+		t.Fatalf("Value() = %g after moving to the end, want the last step 1.6", s.Value())
+	}
+}
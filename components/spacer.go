@@ -2,10 +2,15 @@ package components
 
 import (
 	"strings"
+	"sync"
+
+	"gonic/shared"
 )
 
 // Spacer represents a component that adds space between other components.
 type Spacer struct {
+	shared.Notifier
+	mu   sync.RWMutex
 	size int
 }
 
@@ -18,12 +23,22 @@ func NewSpacer(size int) *Spacer {
 
 // SetSize sets the size of the spacer.
 func (s *Spacer) SetSize(size int) {
+	s.mu.Lock()
 	s.size = size
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// Size returns the size of the spacer.
+func (s *Spacer) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
 }
 
 // Render renders the spacer to a string.
 func (s *Spacer) Render() string {
 	// In a real implementation, this would create space using the rendering backend
 	// For our string-based rendering, we'll just return some newlines
-	return strings.Repeat("\n", s.size)
+	return strings.Repeat("\n", s.Size())
 }
@@ -0,0 +1,109 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+
+	"gonic/shared"
+)
+
+// Switch represents a labelled on/off toggle.
+type Switch struct {
+	shared.Notifier
+	mu       sync.RWMutex
+	label    string
+	on       bool
+	disabled bool
+	onChange ToggleHandler
+}
+
+// NewSwitch creates a new switch that is turned off.
+func NewSwitch(label string, onChange ToggleHandler) *Switch {
+	return &Switch{
+		label:    label,
+		onChange: onChange,
+	}
+}
+
+// SetLabel sets the text shown next to the switch.
+func (s *Switch) SetLabel(label string) {
+	s.mu.Lock()
+	s.label = label
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetOn sets whether the switch is turned on. It does not trigger the OnChange handler.
+func (s *Switch) SetOn(on bool) {
+	s.mu.Lock()
+	s.on = on
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// SetDisabled sets whether the switch is disabled.
+func (s *Switch) SetDisabled(disabled bool) {
+	s.mu.Lock()
+	s.disabled = disabled
+	s.mu.Unlock()
+	s.Notify(s)
+}
+
+// OnChange sets the handler called when the user flips the switch.
+func (s *Switch) OnChange(handler ToggleHandler) {
+	s.mu.Lock()
+	s.onChange = handler
+	s.mu.Unlock()
+}
+
+// Label returns the text shown next to the switch.
+func (s *Switch) Label() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.label
+}
+
+// On reports whether the switch is turned on.
+func (s *Switch) On() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.on
+}
+
+// Disabled reports whether the switch is disabled.
+func (s *Switch) Disabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.disabled
+}
+
+// Change simulates the user flipping the switch, which triggers the OnChange
+// handler. Disabled switches ignore the change.
+func (s *Switch) Change(on bool) {
+	s.mu.Lock()
+	if s.disabled || s.on == on {
+		s.mu.Unlock()
+		rejectChange(s)
+		return
+	}
+	s.on = on
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	s.Notify(s)
+	if onChange != nil {
+		onChange(on)
+	}
+}
+
+// Render renders the switch to a string.
+func (s *Switch) Render() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state := "off"
+	if s.on {
+		state = "on"
+	}
+	return fmt.Sprintf("[Switch: %s (%s)]", s.label, state)
+}
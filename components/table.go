@@ -0,0 +1,387 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gonic/shared"
+)
+
+// SelectionMode sets how many items the user can select.
+type SelectionMode int
+
+const (
+	// NoSelection doesn't let the user select anything.
+	NoSelection SelectionMode = iota
+	// SingleSelection lets the user select one item at a time.
+	SingleSelection
+	// MultiSelection lets the user select any number of items.
+	MultiSelection
+)
+
+// TableColumn defines a column of a Table.
+type TableColumn struct {
+	// Key is the key of the column's values in each row.
+	Key string
+	// Title is shown in the column header.
+	Title string
+	// Sortable lets the user sort the table by clicking the header.
+	Sortable bool
+	// Width is the width of the column in pixels, or zero to size it automatically.
+	Width int
+	// Format turns a cell value into text. Values are printed with fmt by default.
+	Format func(value interface{}) string
+}
+
+// FormatCell returns the text shown for a cell value in the column.
+func (c TableColumn) FormatCell(value interface{}) string {
+	if c.Format != nil {
+		return c.Format(value)
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// TableSelectHandler is a function type for table selection handlers.
+type TableSelectHandler func(rows []TableRow)
+
+// Table represents a grid of rows and columns backed by a row provider.
+type Table struct {
+	shared.Notifier
+	mu            sync.RWMutex
+	columns       []TableColumn
+	provider      TableRowProvider
+	pageSize      int
+	page          int
+	sortKey       string
+	descending    bool
+	selectionMode SelectionMode
+	selected      []TableRow
+	onSelect      TableSelectHandler
+
+	// The rows of the current page, loaded from the provider
+	rows   []TableRow
+	count  int
+	err    error
+	loadMu sync.Mutex
+}
+
+// NewTable creates a new table with the given columns and rows.
+func NewTable(columns []TableColumn, provider TableRowProvider) *Table {
+	t := &Table{
+		columns:  append([]TableColumn(nil), columns...),
+		provider: provider,
+	}
+	t.load()
+	return t
+}
+
+// SetColumns replaces the columns of the table.
+func (t *Table) SetColumns(columns []TableColumn) {
+	t.mu.Lock()
+	t.columns = append([]TableColumn(nil), columns...)
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// SetProvider replaces the row provider and loads the first page.
+func (t *Table) SetProvider(provider TableRowProvider) {
+	t.mu.Lock()
+	t.provider = provider
+	t.page = 0
+	t.selected = nil
+	t.mu.Unlock()
+	t.load()
+}
+
+// SetPageSize sets the number of rows per page. Zero shows all rows at once.
+func (t *Table) SetPageSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+
+	t.mu.Lock()
+	t.pageSize = size
+	t.page = 0
+	t.mu.Unlock()
+	t.load()
+}
+
+// SetPage shows the page with the given index, starting at zero.
+func (t *Table) SetPage(page int) {
+	if page < 0 {
+		page = 0
+	}
+
+	t.mu.Lock()
+	t.page = page
+	t.mu.Unlock()
+	t.load()
+}
+
+// SetSort sorts the table by the column with the given key. An empty key
+// restores the provider's natural order.
+func (t *Table) SetSort(key string, descending bool) {
+	t.mu.Lock()
+	t.sortKey = key
+	t.descending = descending
+	t.page = 0
+	t.mu.Unlock()
+	t.load()
+}
+
+// SetSelectionMode sets how many rows the user can select. Changing the mode clears the selection.
+func (t *Table) SetSelectionMode(mode SelectionMode) {
+	t.mu.Lock()
+	t.selectionMode = mode
+	t.selected = nil
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// SetSelected selects the rows with the given keys on the current page.
+// It does not trigger the OnSelect handler.
+func (t *Table) SetSelected(keys []string) {
+	t.mu.Lock()
+	t.selected = nil
+	for _, key := range keys {
+		if row, ok := t.visibleRow(key); ok {
+			t.selected = append(t.selected, row)
+		}
+	}
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// OnSelect sets the handler called when the user changes the selection.
+func (t *Table) OnSelect(handler TableSelectHandler) {
+	t.mu.Lock()
+	t.onSelect = handler
+	t.mu.Unlock()
+}
+
+// Refresh reloads the current page from the row provider.
+func (t *Table) Refresh() {
+	t.load()
+}
+
+// Columns returns the columns of the table.
+func (t *Table) Columns() []TableColumn {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]TableColumn(nil), t.columns...)
+}
+
+// Rows returns the rows of the current page.
+func (t *Table) Rows() []TableRow {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]TableRow(nil), t.rows...)
+}
+
+// RowCount returns the total number of rows on all pages.
+func (t *Table) RowCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.count
+}
+
+// Err returns the error from the last time rows were loaded, if any.
+func (t *Table) Err() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.err
+}
+
+// PageSize returns the number of rows per page, or zero if all rows are shown.
+func (t *Table) PageSize() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.pageSize
+}
+
+// Page returns the index of the current page.
+func (t *Table) Page() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.page
+}
+
+// PageCount returns the number of pages.
+func (t *Table) PageCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.pageCount()
+}
+
+// Sort returns the key of the column the table is sorted by and whether the order is descending.
+func (t *Table) Sort() (key string, descending bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.sortKey, t.descending
+}
+
+// SelectionMode returns how many rows the user can select.
+func (t *Table) SelectionMode() SelectionMode {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.selectionMode
+}
+
+// Selected returns the selected rows.
+func (t *Table) Selected() []TableRow {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]TableRow(nil), t.selected...)
+}
+
+// IsSelected reports whether the row with the given key is selected.
+func (t *Table) IsSelected(key string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.selectedIndex(key) >= 0
+}
+
+// ToggleSort simulates the user clicking the header of a sortable column. The
+// first click sorts ascending, the next one descending.
+func (t *Table) ToggleSort(key string) {
+	t.mu.RLock()
+	sortable := false
+	for _, column := range t.columns {
+		if column.Key == key {
+			sortable = column.Sortable
+		}
+	}
+	descending := t.sortKey == key && !t.descending
+	t.mu.RUnlock()
+
+	if sortable {
+		t.SetSort(key, descending)
+	}
+}
+
+// Select simulates the user clicking the row with the given key, which triggers
+// the OnSelect handler. With single selection the row replaces the selection,
+// with multi selection it is added to or removed from it.
+func (t *Table) Select(key string) {
+	t.mu.Lock()
+	row, ok := t.visibleRow(key)
+	if !ok || t.selectionMode == NoSelection {
+		t.mu.Unlock()
+		rejectChange(t)
+		return
+	}
+	if t.selectionMode == SingleSelection {
+		t.selected = []TableRow{row}
+	} else if i := t.selectedIndex(key); i >= 0 {
+		t.selected = append(t.selected[:i:i], t.selected[i+1:]...)
+	} else {
+		t.selected = append(t.selected, row)
+	}
+	selected := append([]TableRow(nil), t.selected...)
+	onSelect := t.onSelect
+	t.mu.Unlock()
+
+	t.Notify(t)
+	if onSelect != nil {
+		onSelect(selected)
+	}
+}
+
+// load fetches the current page from the provider. The provider is called
+// without holding the lock, as it may be slow.
+func (t *Table) load() {
+	t.loadMu.Lock()
+	defer t.loadMu.Unlock()
+
+	t.mu.RLock()
+	provider := t.provider
+	t.mu.RUnlock()
+
+	var rows []TableRow
+	count, err := 0, error(nil)
+	if provider != nil {
+		count, err = provider.RowCount()
+	}
+
+	t.mu.Lock()
+	t.count = count
+	// Stay on the last page when rows have been removed
+	if last := t.pageCount() - 1; t.page > last {
+		t.page = last
+	}
+	query := TableQuery{
+		Offset:     t.page * t.pageSize,
+		Limit:      t.pageSize,
+		SortKey:    t.sortKey,
+		Descending: t.descending,
+	}
+	t.mu.Unlock()
+
+	if provider != nil && err == nil {
+		rows, err = provider.Rows(query)
+	}
+
+	t.mu.Lock()
+	t.rows = rows
+	t.err = err
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// pageCount returns the number of pages. The caller must hold the lock.
+func (t *Table) pageCount() int {
+	if t.pageSize <= 0 || t.count <= t.pageSize {
+		return 1
+	}
+	return (t.count + t.pageSize - 1) / t.pageSize
+}
+
+// visibleRow returns the row with the given key on the current page.
+// The caller must hold the lock.
+func (t *Table) visibleRow(key string) (TableRow, bool) {
+	for _, row := range t.rows {
+		if row.Key == key {
+			return row, true
+		}
+	}
+	return TableRow{}, false
+}
+
+// selectedIndex returns the position of a row in the selection, or -1.
+// The caller must hold the lock.
+func (t *Table) selectedIndex(key string) int {
+	for i, row := range t.selected {
+		if row.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Render renders the table to a string.
+func (t *Table) Render() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	lines := make([]string, 0, len(t.rows)+1)
+	titles := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		titles[i] = column.Title
+	}
+	lines = append(lines, strings.Join(titles, " | "))
+	for _, row := range t.rows {
+		cells := make([]string, len(t.columns))
+		for i, column := range t.columns {
+			cells[i] = column.FormatCell(row.Cells[column.Key])
+		}
+		lines = append(lines, strings.Join(cells, " | "))
+	}
+	if t.pageSize > 0 {
+		lines = append(lines, fmt.Sprintf("Page %d of %d", t.page+1, t.pageCount()))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,182 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TableRow is a single row of a Table.
+type TableRow struct {
+	// Key identifies the row across pages and sort orders.
+	Key string
+	// Cells maps column keys to cell values.
+	Cells map[string]interface{}
+}
+
+// TableQuery describes the rows a Table wants to show.
+type TableQuery struct {
+	// Offset is the index of the first row.
+	Offset int
+	// Limit is the maximum number of rows, or zero for all rows.
+	Limit int
+	// SortKey is the key of the column to sort by, or empty for the natural order.
+	SortKey string
+	// Descending reverses the sort order.
+	Descending bool
+}
+
+// TableRowProvider supplies the rows of a Table. Sorting and paging are left
+// to the provider, so it can hand them on to a database or a paged API.
+type TableRowProvider interface {
+	// RowCount returns the total number of rows.
+	RowCount() (int, error)
+	// Rows returns the rows matching the query.
+	Rows(query TableQuery) ([]TableRow, error)
+}
+
+// SliceRowProvider provides table rows held in memory.
+type SliceRowProvider struct {
+	mu   sync.RWMutex
+	rows []TableRow
+}
+
+// NewSliceRowProvider creates a row provider for the given rows.
+func NewSliceRowProvider(rows []TableRow) *SliceRowProvider {
+	return &SliceRowProvider{
+		rows: append([]TableRow(nil), rows...),
+	}
+}
+
+// SetRows replaces the rows. Call Refresh on the table to show them.
+func (p *SliceRowProvider) SetRows(rows []TableRow) {
+	p.mu.Lock()
+	p.rows = append([]TableRow(nil), rows...)
+	p.mu.Unlock()
+}
+
+// RowCount returns the number of rows.
+func (p *SliceRowProvider) RowCount() (int, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.rows), nil
+}
+
+// Rows returns the rows matching the query.
+func (p *SliceRowProvider) Rows(query TableQuery) ([]TableRow, error) {
+	p.mu.RLock()
+	rows := append([]TableRow(nil), p.rows...)
+	p.mu.RUnlock()
+
+	if query.SortKey != "" {
+		sort.SliceStable(rows, func(i, j int) bool {
+			a, b := rows[i].Cells[query.SortKey], rows[j].Cells[query.SortKey]
+			if query.Descending {
+				return compareValues(b, a) < 0
+			}
+			return compareValues(a, b) < 0
+		})
+	}
+
+	if query.Offset >= len(rows) {
+		return nil, nil
+	}
+	rows = rows[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(rows) {
+		rows = rows[:query.Limit]
+	}
+	return rows, nil
+}
+
+// compareValues orders two cell values. Numbers, strings, times and booleans
+// compare naturally, anything else by its string form. Empty cells sort first.
+func compareValues(a, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return compareOrdered(x, y)
+		}
+	}
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return compareOrdered(x, y)
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			default:
+				return 0
+			}
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			if x == y {
+				return 0
+			}
+			if !x {
+				return -1
+			}
+			return 1
+		}
+	}
+	return compareOrdered(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// compareOrdered returns -1, 0 or 1 depending on how a compares to b.
+func compareOrdered[T int | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// toFloat converts any numeric value to a float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,117 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompareValues(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		a, b interface{}
+		want int
+	}{
+		{"ints", 2, 10, -1},
+		{"int and float", 2, 1.5, 1},
+		{"equal int and float", int64(3), float32(3), 0},
+		{"unsigned and negative", uint8(0), -1, 1},
+		{"strings", "b", "a", 1},
+		{"times", now, now.Add(time.Second), -1},
+		{"booleans", true, false, 1},
+		{"nil first", nil, 0, -1},
+		{"nil last", "", nil, 1},
+		{"two nils", nil, nil, 0},
+		{"number and string", 10, "9", -1},
+		{"string and number", "abc", 2, 1},
+		{"bool and string", false, "true", -1},
+		{"number and bool", 1, true, -1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := compareValues(test.a, test.b); got != test.want {
+				t.Fatalf("compareValues(%v, %v) = %d, want %d", test.a, test.b, got, test.want)
+			}
+			if got := compareValues(test.b, test.a); got != -test.want {
+				t.Fatalf("compareValues(%v, %v) = %d, want %d", test.b, test.a, got, -test.want)
+			}
+		})
+	}
+}
+
+// keys returns the keys of rows
+func keys(rows []TableRow) []string {
+	var keys []string
+	for _, row := range rows {
+		keys = append(keys, row.Key)
+	}
+	return keys
+}
+
+// sizes creates a row provider whose rows have a mix of size values
+func sizes() *SliceRowProvider {
+	return NewSliceRowProvider([]TableRow{
+		{Key: "a", Cells: map[string]interface{}{"size": 3}},
+		{Key: "b", Cells: map[string]interface{}{"size": 1.5}},
+		{Key: "c", Cells: map[string]interface{}{}},
+		{Key: "d", Cells: map[string]interface{}{"size": int64(3)}},
+		{Key: "e", Cells: map[string]interface{}{"size": 10}},
+	})
+}
+
+func TestSliceRowProviderRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		query TableQuery
+		want  []string
+	}{
+		{"natural order", TableQuery{}, []string{"a", "b", "c", "d", "e"}},
+		{"ascending", TableQuery{SortKey: "size"}, []string{"c", "b", "a", "d", "e"}},
+		{"descending", TableQuery{SortKey: "size", Descending: true}, []string{"e", "a", "d", "b", "c"}},
+		{"first page", TableQuery{Limit: 2, SortKey: "size"}, []string{"c", "b"}},
+		{"last partial page", TableQuery{Offset: 4, Limit: 2, SortKey: "size"}, []string{"e"}},
+		{"page past the end", TableQuery{Offset: 6, Limit: 2}, nil},
+		{"offset at the end", TableQuery{Offset: 5, Limit: 2}, nil},
+		{"offset without limit", TableQuery{Offset: 3}, []string{"d", "e"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := sizes().Rows(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := keys(rows); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("Rows(%+v) returned %v, want %v", test.query, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTablePages(t *testing.T) {
+	provider := sizes()
+	table := NewTable([]TableColumn{{Key: "size", Title: "Size"}}, provider)
+	table.SetPageSize(2)
+	if table.PageCount() != 3 {
+		t.Fatalf("PageCount() = %d for 5 rows, want 3", table.PageCount())
+	}
+
+	table.SetSort("size", true)
+	table.SetPage(2)
+	if got := keys(table.Rows()); table.Page() != 2 || !reflect.DeepEqual(got, []string{"c"}) {
+		t.Fatalf("page %d shows %v, want page 2 with c", table.Page(), got)
+	}
+
+	// Pages past the end show the last page
+	table.SetPage(7)
+	if got := keys(table.Rows()); table.Page() != 2 || !reflect.DeepEqual(got, []string{"c"}) {
+		t.Fatalf("page %d shows %v, want page 2 with c", table.Page(), got)
+	}
+
+	// Removing rows moves back to the new last page
+	provider.SetRows([]TableRow{{Key: "x"}, {Key: "y"}})
+	table.Refresh()
+	if got := keys(table.Rows()); table.Page() != 0 || table.PageCount() != 1 || !reflect.DeepEqual(got, []string{"x", "y"}) {
+		t.Fatalf("page %d of %d shows %v, want the only page with x and y", table.Page(), table.PageCount(), got)
+	}
+}
@@ -0,0 +1,191 @@
+package components
+
+import (
+	"strings"
+	"sync"
+
+	"gonic/layout"
+	"gonic/shared"
+)
+
+// Tab is a page of a Tabs container.
+type Tab struct {
+	// Title is shown on the tab.
+	Title string
+	// Content is the layout shown while the tab is selected.
+	Content shared.Layout
+}
+
+// TabChangeHandler is a function type for tab change handlers.
+type TabChangeHandler func(index int)
+
+// Tabs represents a container that shows one of several pages, chosen by
+// clicking the tab titles.
+type Tabs struct {
+	shared.Notifier
+	mu          sync.RWMutex
+	tabs        []Tab
+	current     int
+	onTabChange TabChangeHandler
+}
+
+// NewTabs creates a new tabs container with the given tabs. The first tab is selected.
+func NewTabs(tabs ...Tab) *Tabs {
+	return &Tabs{
+		tabs: append([]Tab(nil), tabs...),
+	}
+}
+
+// AddTab adds a tab at the end.
+func (t *Tabs) AddTab(title string, content shared.Layout) {
+	t.mu.Lock()
+	t.tabs = append(t.tabs, Tab{Title: title, Content: content})
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// RemoveTab removes the tab at the given index. The selected tab stays
+// selected if it remains, otherwise the tab before it is selected.
+func (t *Tabs) RemoveTab(index int) {
+	t.mu.Lock()
+	if index < 0 || index >= len(t.tabs) {
+		t.mu.Unlock()
+		return
+	}
+	t.tabs = append(t.tabs[:index:index], t.tabs[index+1:]...)
+	if t.current > index || t.current == len(t.tabs) {
+		t.current--
+	}
+	if t.current < 0 {
+		t.current = 0
+	}
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// SetTitle sets the title of the tab at the given index.
+func (t *Tabs) SetTitle(index int, title string) {
+	t.mu.Lock()
+	if index < 0 || index >= len(t.tabs) {
+		t.mu.Unlock()
+		return
+	}
+	t.tabs[index].Title = title
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// SetCurrent selects the tab at the given index. Indexes out of range are
+// ignored. It does not trigger the OnTabChange handler.
+func (t *Tabs) SetCurrent(index int) {
+	t.mu.Lock()
+	if index < 0 || index >= len(t.tabs) {
+		t.mu.Unlock()
+		return
+	}
+	t.current = index
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// OnTabChange sets the handler called when the user selects another tab.
+func (t *Tabs) OnTabChange(handler TabChangeHandler) {
+	t.mu.Lock()
+	t.onTabChange = handler
+	t.mu.Unlock()
+}
+
+// Tabs returns the tabs of the container.
+func (t *Tabs) Tabs() []Tab {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]Tab(nil), t.tabs...)
+}
+
+// Current returns the index of the selected tab, or -1 if there are no tabs.
+func (t *Tabs) Current() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.tabs) == 0 {
+		return -1
+	}
+	return t.current
+}
+
+// CurrentTab returns the selected tab and whether there is one.
+func (t *Tabs) CurrentTab() (Tab, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.tabs) == 0 {
+		return Tab{}, false
+	}
+	return t.tabs[t.current], true
+}
+
+// SelectTab simulates the user clicking the tab at the given index, which
+// triggers the OnTabChange handler.
+func (t *Tabs) SelectTab(index int) {
+	t.mu.Lock()
+	if index < 0 || index >= len(t.tabs) || index == t.current {
+		t.mu.Unlock()
+		return
+	}
+	t.current = index
+	onTabChange := t.onTabChange
+	t.mu.Unlock()
+
+	t.Notify(t)
+	if onTabChange != nil {
+		onTabChange(index)
+	}
+}
+
+// bar returns the text of the tab bar and the page of the selected tab, or
+// nil if there is none.
+func (t *Tabs) bar() (string, shared.Component) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	titles := make([]string, len(t.tabs))
+	for i, tab := range t.tabs {
+		titles[i] = tab.Title
+	}
+	if len(t.tabs) == 0 || t.tabs[t.current].Content == nil {
+		return strings.Join(titles, "  "), nil
+	}
+	return strings.Join(titles, "  "), t.tabs[t.current].Content
+}
+
+// MeasureLayout returns the range of sizes of the tab bar above the page of
+// the selected tab.
+func (t *Tabs) MeasureLayout(e *layout.Engine) layout.SizeHint {
+	text, page := t.bar()
+	return measureWithHeader(e, text, page)
+}
+
+// ArrangeLayout places the page of the selected tab below the tab bar. The
+// pages of the other tabs aren't shown, so they get no box.
+func (t *Tabs) ArrangeLayout(e *layout.Engine, bounds layout.Rect) []*layout.Box {
+	text, page := t.bar()
+	return arrangeWithHeader(e, text, page, bounds)
+}
+
+// Render renders the tab titles and the selected tab to a string.
+func (t *Tabs) Render() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	titles := make([]string, len(t.tabs))
+	for i, tab := range t.tabs {
+		if i == t.current {
+			titles[i] = "[" + tab.Title + "]"
+		} else {
+			titles[i] = tab.Title
+		}
+	}
+	lines := []string{strings.Join(titles, " | ")}
+	if len(t.tabs) > 0 && t.tabs[t.current].Content != nil {
+		lines = append(lines, t.tabs[t.current].Content.Render())
+	}
+	return strings.Join(lines, "\n")
+}
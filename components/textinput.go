@@ -0,0 +1,213 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gonic/shared"
+)
+
+// TextInputKind selects how a text input is displayed.
+type TextInputKind int
+
+const (
+	// SingleLineInput is a plain one-line text field.
+	SingleLineInput TextInputKind = iota
+	// PasswordInput is a one-line text field that hides what is typed.
+	PasswordInput
+	// MultiLineInput is a text area that accepts several lines.
+	MultiLineInput
+)
+
+// TextChangeHandler is a function type for text input event handlers.
+type TextChangeHandler func(value string)
+
+// TextInput represents an editable text field.
+type TextInput struct {
+	shared.Notifier
+	mu          sync.RWMutex
+	kind        TextInputKind
+	value       string
+	placeholder string
+	maxLength   int
+	readOnly    bool
+	onChange    TextChangeHandler
+	onSubmit    TextChangeHandler
+}
+
+// NewTextInput creates a new single-line text input with the given placeholder.
+func NewTextInput(placeholder string) *TextInput {
+	return &TextInput{
+		kind:        SingleLineInput,
+		placeholder: placeholder,
+	}
+}
+
+// NewPasswordInput creates a new password input with the given placeholder.
+func NewPasswordInput(placeholder string) *TextInput {
+	return &TextInput{
+		kind:        PasswordInput,
+		placeholder: placeholder,
+	}
+}
+
+// NewMultiLineInput creates a new multi-line text input with the given placeholder.
+func NewMultiLineInput(placeholder string) *TextInput {
+	return &TextInput{
+		kind:        MultiLineInput,
+		placeholder: placeholder,
+	}
+}
+
+// SetValue sets the text of the input. It does not trigger the OnChange handler.
+func (t *TextInput) SetValue(value string) {
+	t.mu.Lock()
+	t.value = truncate(value, t.maxLength)
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// SetPlaceholder sets the text shown while the input is empty.
+func (t *TextInput) SetPlaceholder(placeholder string) {
+	t.mu.Lock()
+	t.placeholder = placeholder
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// SetMaxLength sets the maximum number of characters the input accepts.
+// Zero means there is no limit.
+func (t *TextInput) SetMaxLength(maxLength int) {
+	t.mu.Lock()
+	t.maxLength = maxLength
+	t.value = truncate(t.value, maxLength)
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// SetReadOnly sets whether the user can edit the input.
+func (t *TextInput) SetReadOnly(readOnly bool) {
+	t.mu.Lock()
+	t.readOnly = readOnly
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// OnChange sets the handler called whenever the user changes the text.
+func (t *TextInput) OnChange(handler TextChangeHandler) {
+	t.mu.Lock()
+	t.onChange = handler
+	t.mu.Unlock()
+}
+
+// OnSubmit sets the handler called when the user submits the input,
+// for example by pressing Enter.
+func (t *TextInput) OnSubmit(handler TextChangeHandler) {
+	t.mu.Lock()
+	t.onSubmit = handler
+	t.mu.Unlock()
+}
+
+// Kind returns how the input is displayed.
+func (t *TextInput) Kind() TextInputKind {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.kind
+}
+
+// Value returns the text of the input.
+func (t *TextInput) Value() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.value
+}
+
+// Placeholder returns the text shown while the input is empty.
+func (t *TextInput) Placeholder() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.placeholder
+}
+
+// MaxLength returns the maximum number of characters, or zero if there is no limit.
+func (t *TextInput) MaxLength() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.maxLength
+}
+
+// ReadOnly reports whether the user can't edit the input.
+func (t *TextInput) ReadOnly() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.readOnly
+}
+
+// Change simulates the user typing a new value, which triggers the OnChange
+// handler. Values longer than the maximum length are cut off, and read-only
+// inputs ignore the change.
+func (t *TextInput) Change(value string) {
+	t.mu.Lock()
+	if t.readOnly {
+		t.mu.Unlock()
+		rejectChange(t)
+		return
+	}
+	limited := truncate(value, t.maxLength)
+	if limited == t.value {
+		t.mu.Unlock()
+		if limited != value {
+			// Cut the extra characters off the displayed text
+			t.Notify(t)
+		}
+		return
+	}
+	t.value = limited
+	onChange := t.onChange
+	t.mu.Unlock()
+
+	t.Notify(t)
+	if onChange != nil {
+		onChange(limited)
+	}
+}
+
+// Submit simulates the user submitting the input, which triggers the OnSubmit handler.
+func (t *TextInput) Submit() {
+	t.mu.RLock()
+	onSubmit, value := t.onSubmit, t.value
+	t.mu.RUnlock()
+
+	if onSubmit != nil {
+		onSubmit(value)
+	}
+}
+
+// Render renders the text input to a string.
+func (t *TextInput) Render() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	value := t.value
+	if t.kind == PasswordInput {
+		value = strings.Repeat("*", len([]rune(value)))
+	}
+	if value == "" {
+		return fmt.Sprintf("[TextInput: (%s)]", t.placeholder)
+	}
+	return fmt.Sprintf("[TextInput: %s]", value)
+}
+
+// truncate cuts a string down to at most maxLength characters.
+// A maxLength of zero or less means there is no limit.
+func truncate(s string, maxLength int) string {
+	if maxLength <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	return string(runes[:maxLength])
+}
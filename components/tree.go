@@ -0,0 +1,260 @@
+package components
+
+import (
+	"strings"
+	"sync"
+
+	"gonic/shared"
+)
+
+// TreeNode is a node of a Tree.
+type TreeNode struct {
+	// ID identifies the node and must be unique within the tree.
+	ID string
+	// Label is the text shown for the node.
+	Label string
+	// Icon is shown before the label, such as an emoji.
+	Icon string
+	// Leaf marks nodes that can't have children.
+	Leaf bool
+}
+
+// TreeChildrenFunc loads the children of the node with the given ID. The
+// top-level nodes are the children of the empty ID.
+type TreeChildrenFunc func(parentID string) ([]TreeNode, error)
+
+// TreeNodeHandler is a function type for tree node event handlers.
+type TreeNodeHandler func(node TreeNode)
+
+// Tree represents a hierarchy of nodes that can be expanded and collapsed.
+// Children are loaded on demand the first time their parent is expanded.
+type Tree struct {
+	shared.Notifier
+	mu            sync.RWMutex
+	loadChildren  TreeChildrenFunc
+	nodes         map[string]TreeNode
+	children      map[string][]TreeNode
+	errors        map[string]error
+	expanded      map[string]bool
+	selected      string
+	onSelect      TreeNodeHandler
+	onDoubleClick TreeNodeHandler
+}
+
+// NewTree creates a new tree whose nodes are loaded by loadChildren.
+// The top-level nodes are loaded straight away.
+func NewTree(loadChildren TreeChildrenFunc) *Tree {
+	t := &Tree{
+		loadChildren: loadChildren,
+		nodes:        make(map[string]TreeNode),
+		children:     make(map[string][]TreeNode),
+		errors:       make(map[string]error),
+		expanded:     make(map[string]bool),
+	}
+	t.load("")
+	return t
+}
+
+// OnSelect sets the handler called when the user selects a node.
+func (t *Tree) OnSelect(handler TreeNodeHandler) {
+	t.mu.Lock()
+	t.onSelect = handler
+	t.mu.Unlock()
+}
+
+// OnDoubleClick sets the handler called when the user double-clicks a node.
+func (t *Tree) OnDoubleClick(handler TreeNodeHandler) {
+	t.mu.Lock()
+	t.onDoubleClick = handler
+	t.mu.Unlock()
+}
+
+// Expand shows the children of a node, loading them first if needed. Nodes
+// whose children failed to load are loaded again.
+func (t *Tree) Expand(id string) {
+	t.mu.RLock()
+	expanded := t.expanded[id]
+	_, loaded := t.children[id]
+	loaded = loaded && t.errors[id] == nil
+	t.mu.RUnlock()
+	if expanded && loaded {
+		return
+	}
+
+	if !loaded {
+		t.load(id)
+	}
+	t.mu.Lock()
+	t.expanded[id] = true
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// Collapse hides the children of a node.
+func (t *Tree) Collapse(id string) {
+	t.mu.Lock()
+	if !t.expanded[id] {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.expanded, id)
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// Toggle expands a collapsed node or collapses an expanded one.
+func (t *Tree) Toggle(id string) {
+	if t.IsExpanded(id) {
+		t.Collapse(id)
+	} else {
+		t.Expand(id)
+	}
+}
+
+// Reload loads the children of a node again, for example after files were
+// added to a directory. Use the empty ID to reload the top-level nodes.
+func (t *Tree) Reload(id string) {
+	t.load(id)
+	t.Notify(t)
+}
+
+// SetSelected selects the node with the given ID, or clears the selection for
+// an unknown ID. It does not trigger the OnSelect handler.
+func (t *Tree) SetSelected(id string) {
+	t.mu.Lock()
+	if _, ok := t.nodes[id]; !ok {
+		id = ""
+	}
+	t.selected = id
+	t.mu.Unlock()
+	t.Notify(t)
+}
+
+// Node returns the loaded node with the given ID.
+func (t *Tree) Node(id string) (TreeNode, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node, ok := t.nodes[id]
+	return node, ok
+}
+
+// Children returns the loaded children of a node and whether they have been loaded.
+func (t *Tree) Children(id string) ([]TreeNode, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	children, ok := t.children[id]
+	return append([]TreeNode(nil), children...), ok
+}
+
+// Err returns the error from loading the children of a node, if any.
+func (t *Tree) Err(id string) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.errors[id]
+}
+
+// IsExpanded reports whether the children of a node are shown.
+func (t *Tree) IsExpanded(id string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.expanded[id]
+}
+
+// Selected returns the selected node and whether a node is selected.
+func (t *Tree) Selected() (TreeNode, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node, ok := t.nodes[t.selected]
+	return node, ok
+}
+
+// Select simulates the user clicking a node, which triggers the OnSelect handler.
+func (t *Tree) Select(id string) {
+	t.mu.Lock()
+	node, ok := t.nodes[id]
+	if !ok || t.selected == id {
+		t.mu.Unlock()
+		return
+	}
+	t.selected = id
+	onSelect := t.onSelect
+	t.mu.Unlock()
+
+	t.Notify(t)
+	if onSelect != nil {
+		onSelect(node)
+	}
+}
+
+// DoubleClick simulates the user double-clicking a node, which selects it
+// and triggers the OnDoubleClick handler.
+func (t *Tree) DoubleClick(id string) {
+	t.Select(id)
+
+	t.mu.RLock()
+	node, ok := t.nodes[id]
+	onDoubleClick := t.onDoubleClick
+	t.mu.RUnlock()
+
+	if ok && onDoubleClick != nil {
+		onDoubleClick(node)
+	}
+}
+
+// load fetches the children of a node. The callback is called without
+// holding the lock, as it may be slow.
+func (t *Tree) load(id string) {
+	t.mu.RLock()
+	loadChildren := t.loadChildren
+	t.mu.RUnlock()
+
+	var children []TreeNode
+	var err error
+	if loadChildren != nil {
+		children, err = loadChildren(id)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, child := range t.children[id] {
+		delete(t.nodes, child.ID)
+	}
+	if err != nil {
+		t.errors[id] = err
+		t.children[id] = nil
+		return
+	}
+	delete(t.errors, id)
+	t.children[id] = children
+	for _, child := range children {
+		t.nodes[child.ID] = child
+	}
+}
+
+// Render renders the expanded part of the tree to a string.
+func (t *Tree) Render() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var b strings.Builder
+	t.renderChildren(&b, "", 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderChildren writes the children of a node, indented by depth.
+// The caller must hold the lock.
+func (t *Tree) renderChildren(b *strings.Builder, id string, depth int) {
+	for _, child := range t.children[id] {
+		marker := "  "
+		if !child.Leaf {
+			marker = "+ "
+			if t.expanded[child.ID] {
+				marker = "- "
+			}
+		}
+		b.WriteString(strings.Repeat("  ", depth) + marker + strings.TrimSpace(child.Icon+" "+child.Label) + "\n")
+		if t.expanded[child.ID] {
+			t.renderChildren(b, child.ID, depth+1)
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package gonic
 
 import (
+	"context"
 	"fmt"
 	"log"
 )
@@ -16,7 +17,7 @@ func initNativeRenderer() bool {
 // runNativeWindow runs a single window with the native renderer
 func runNativeWindow(window *Window) {
 	// Use the existing native app runner with a single window
-	runNativeApp([]*Window{window})
+	LogError(runNativeApp(context.Background(), []*Window{window}))
 }
 
 // ShowAlert is an alias for Alert for backward compatibility
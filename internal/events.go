@@ -67,9 +67,11 @@ const (
 	KeyX
 	KeyY
 	KeyZ
+)
 
+const (
 	// KeyEscape is the escape key.
-	KeyEscape = 256
+	KeyEscape KeyCode = iota + 256
 	// KeyEnter is the enter/return key.
 	KeyEnter
 	// KeySpace is the space key.
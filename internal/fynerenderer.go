@@ -31,12 +31,28 @@ func NewFyneRenderer() *FyneRenderer {
 	}
 }
 
+// NewFyneRendererWithApp creates a Fyne renderer that uses an existing app,
+// such as one created by Fyne's test package.
+func NewFyneRendererWithApp(fyneApp fyne.App) *FyneRenderer {
+	return &FyneRenderer{
+		app:     fyneApp,
+		windows: make(map[string]*fyneWindow),
+	}
+}
+
 // Initialize initializes the Fyne renderer.
 func (r *FyneRenderer) Initialize() error {
-	r.app = app.New()
+	if r.app == nil {
+		r.app = app.New()
+	}
 	return nil
 }
 
+// App returns the underlying Fyne app, or nil before Initialize is called.
+func (r *FyneRenderer) App() fyne.App {
+	return r.app
+}
+
 // Shutdown shuts down the Fyne renderer.
 func (r *FyneRenderer) Shutdown() {
 	// Fyne doesn't require explicit shutdown
@@ -0,0 +1,258 @@
+package layout
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// BorderLayout arranges components along the edges of its area, the way
+// application windows put a header, sidebars and a footer around their
+// content. The top and bottom components span the whole width, the left and
+// right ones sit between them, and the center takes the remaining space.
+// Components added with Add are stacked vertically in the center.
+type BorderLayout struct {
+	BaseLayout
+	top    Component
+	bottom Component
+	left   Component
+	right  Component
+}
+
+// NewBorderLayout creates a new border layout. Any of the edges may be nil.
+func NewBorderLayout(top, bottom, left, right Component, center ...Component) *BorderLayout {
+	l := &BorderLayout{
+		BaseLayout: BaseLayout{
+			components: append([]Component(nil), center...),
+		},
+		top:    top,
+		bottom: bottom,
+		left:   left,
+		right:  right,
+	}
+	l.owner = l
+	return l
+}
+
+// SetTop sets the component along the top edge, or removes it if c is nil.
+func (l *BorderLayout) SetTop(c Component) {
+	l.setEdge(&l.top, c)
+}
+
+// SetBottom sets the component along the bottom edge, or removes it if c is nil.
+func (l *BorderLayout) SetBottom(c Component) {
+	l.setEdge(&l.bottom, c)
+}
+
+// SetLeft sets the component along the left edge, or removes it if c is nil.
+func (l *BorderLayout) SetLeft(c Component) {
+	l.setEdge(&l.left, c)
+}
+
+// SetRight sets the component along the right edge, or removes it if c is nil.
+func (l *BorderLayout) SetRight(c Component) {
+	l.setEdge(&l.right, c)
+}
+
+// setEdge replaces the component along an edge.
+func (l *BorderLayout) setEdge(edge *Component, c Component) {
+	l.mu.Lock()
+	*edge = c
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetCenter replaces the components in the center.
+func (l *BorderLayout) SetCenter(components ...Component) {
+	l.mu.Lock()
+	l.components = append([]Component(nil), components...)
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Top returns the component along the top edge, or nil if there is none.
+func (l *BorderLayout) Top() Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.top
+}
+
+// Bottom returns the component along the bottom edge, or nil if there is none.
+func (l *BorderLayout) Bottom() Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.bottom
+}
+
+// Left returns the component along the left edge, or nil if there is none.
+func (l *BorderLayout) Left() Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.left
+}
+
+// Right returns the component along the right edge, or nil if there is none.
+func (l *BorderLayout) Right() Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.right
+}
+
+// Center returns a copy of the components in the center.
+func (l *BorderLayout) Center() []Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]Component(nil), l.components...)
+}
+
+// Components returns a copy of all components in the layout: the edges that
+// are set, in the order top, left, right and bottom, followed by the center.
+func (l *BorderLayout) Components() []Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var components []Component
+	for _, edge := range []Component{l.top, l.left, l.right, l.bottom} {
+		if edge != nil {
+			components = append(components, edge)
+		}
+	}
+	return append(components, l.components...)
+}
+
+// MeasureLayout returns the range of sizes of the edges around the center.
+func (l *BorderLayout) MeasureLayout(e *Engine) SizeHint {
+	spacing := l.Spacing()
+	var middle []flexItem
+	for _, edge := range []Component{l.Left(), l.Right()} {
+		if edge != nil {
+			middle = append(middle, sizedItem(e.Measure(edge)))
+		}
+	}
+	if center := newFlexItems(e, l.Center()); len(center) > 0 {
+		middle = append(middle, sizedItem(measureLine(center, verticalAxis, spacing)))
+	}
+
+	var rows []flexItem
+	for _, edge := range []Component{l.Top(), l.Bottom()} {
+		if edge != nil {
+			rows = append(rows, sizedItem(e.Measure(edge)))
+		}
+	}
+	if len(middle) > 0 {
+		rows = append(rows, sizedItem(measureLine(middle, horizontalAxis, spacing)))
+	}
+	return measureLine(rows, verticalAxis, spacing).padded(l.Padding())
+}
+
+// ArrangeLayout gives the edges their preferred size across the layout and
+// the center the rest. A single center component fills the center, several
+// are stacked from the top.
+func (l *BorderLayout) ArrangeLayout(e *Engine, bounds Rect) []*Box {
+	bounds = bounds.inset(l.Padding())
+	spacing := l.Spacing()
+	top, bottom, left, right := l.Top(), l.Bottom(), l.Left(), l.Right()
+	x, y := bounds.X, bounds.Y
+	endX, endY := bounds.X+bounds.Width, bounds.Y+bounds.Height
+
+	var boxes []*Box
+	if top != nil {
+		height := minInt(e.Measure(top).Preferred.Height, endY-y)
+		boxes = append(boxes, e.Arrange(top, Rect{X: x, Y: y, Width: endX - x, Height: height}))
+		y += height + spacing
+	}
+	if bottom != nil {
+		height := minInt(e.Measure(bottom).Preferred.Height, nonNegative(endY-y))
+		boxes = append(boxes, e.Arrange(bottom, Rect{X: x, Y: endY - height, Width: endX - x, Height: height}))
+		endY -= height + spacing
+	}
+	if left != nil {
+		width := minInt(e.Measure(left).Preferred.Width, endX-x)
+		boxes = append(boxes, e.Arrange(left, Rect{X: x, Y: y, Width: width, Height: nonNegative(endY - y)}))
+		x += width + spacing
+	}
+	if right != nil {
+		width := minInt(e.Measure(right).Preferred.Width, nonNegative(endX-x))
+		boxes = append(boxes, e.Arrange(right, Rect{X: endX - width, Y: y, Width: width, Height: nonNegative(endY - y)}))
+		endX -= width + spacing
+	}
+
+	center := newFlexItems(e, l.Center())
+	if len(center) == 1 {
+		center[0].grow = 1
+	}
+	area := Rect{X: x, Y: y, Width: nonNegative(endX - x), Height: nonNegative(endY - y)}
+	return append(boxes, arrangeLine(e, center, verticalAxis, area, spacing)...)
+}
+
+// Render renders the layout to a string, with the left edge, the center and
+// the right edge side by side between the top and bottom edges.
+func (l *BorderLayout) Render() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	center := make([]string, 0, len(l.components))
+	for _, c := range l.components {
+		if c != nil {
+			center = append(center, c.Render())
+		}
+	}
+
+	var columns []string
+	for _, column := range []string{render(l.left), strings.Join(center, "\n"), render(l.right)} {
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	var rows []string
+	for _, row := range []string{render(l.top), sideBySide(columns, " | "), render(l.bottom)} {
+		if row != "" {
+			rows = append(rows, row)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// render renders a component that may be nil.
+func render(c Component) string {
+	if c == nil {
+		return ""
+	}
+	return c.Render()
+}
+
+// sideBySide joins multi-line blocks of text into columns, padding every
+// line of a block to the width of its widest line.
+func sideBySide(blocks []string, separator string) string {
+	lines := make([][]string, len(blocks))
+	widths := make([]int, len(blocks))
+	rows := 0
+	for i, block := range blocks {
+		lines[i] = strings.Split(block, "\n")
+		for _, line := range lines[i] {
+			if width := utf8.RuneCountInString(line); width > widths[i] {
+				widths[i] = width
+			}
+		}
+		if len(lines[i]) > rows {
+			rows = len(lines[i])
+		}
+	}
+
+	result := make([]string, rows)
+	for row := range result {
+		parts := make([]string, len(blocks))
+		for i := range blocks {
+			var line string
+			if row < len(lines[i]) {
+				line = lines[i][row]
+			}
+			if i < len(blocks)-1 {
+				line += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(line))
+			}
+			parts[i] = line
+		}
+		result[row] = strings.Join(parts, separator)
+	}
+	return strings.Join(result, "\n")
+}
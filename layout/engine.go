@@ -0,0 +1,626 @@
+package layout
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Size is a width and height in pixels.
+type Size struct {
+	Width  int
+	Height int
+}
+
+// Rect is an area in pixels, from its top-left corner.
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Contains reports whether a point lies inside the rectangle.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Size returns the width and height of the rectangle.
+func (r Rect) Size() Size {
+	return Size{Width: r.Width, Height: r.Height}
+}
+
+// inset returns the rectangle shrunk by the same amount on every side.
+func (r Rect) inset(n int) Rect {
+	return Rect{
+		X:      r.X + n,
+		Y:      r.Y + n,
+		Width:  nonNegative(r.Width - 2*n),
+		Height: nonNegative(r.Height - 2*n),
+	}
+}
+
+// SizeHint is the range of sizes a component can be shown at.
+type SizeHint struct {
+	// Min is the smallest size the component fits in.
+	Min Size
+	// Preferred is the size the component needs to be shown in full.
+	Preferred Size
+}
+
+// padded returns the size hint grown by the same amount on every side.
+func (h SizeHint) padded(n int) SizeHint {
+	return SizeHint{
+		Min:       Size{Width: h.Min.Width + 2*n, Height: h.Min.Height + 2*n},
+		Preferred: Size{Width: h.Preferred.Width + 2*n, Height: h.Preferred.Height + 2*n},
+	}
+}
+
+// Measurer measures components that aren't layouts.
+type Measurer interface {
+	// Measure returns the range of sizes a component can be shown at.
+	Measure(c Component) SizeHint
+}
+
+// Arranger is implemented by layouts to take part in the layout pass.
+type Arranger interface {
+	// MeasureLayout returns the range of sizes the layout can be shown at,
+	// measuring its children with the engine.
+	MeasureLayout(e *Engine) SizeHint
+	// ArrangeLayout places the children of the layout inside bounds.
+	ArrangeLayout(e *Engine, bounds Rect) []*Box
+}
+
+// Box is the area given to a component by the layout pass. The boxes of a
+// layout's children are nested inside its own.
+type Box struct {
+	Component Component
+	Rect      Rect
+	Children  []*Box
+}
+
+// HitTest returns the innermost box containing a point, or nil if the point
+// is outside the box.
+func (b *Box) HitTest(x, y int) *Box {
+	if b == nil || !b.Rect.Contains(x, y) {
+		return nil
+	}
+	// Later children are drawn on top, so they are hit first
+	for i := len(b.Children) - 1; i >= 0; i-- {
+		if hit := b.Children[i].HitTest(x, y); hit != nil {
+			return hit
+		}
+	}
+	return b
+}
+
+// Find returns the box of a component, or nil if it isn't in the tree.
+func (b *Box) Find(c Component) *Box {
+	if b == nil {
+		return nil
+	}
+	if b.Component == c {
+		return b
+	}
+	for _, child := range b.Children {
+		if found := child.Find(c); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Walk calls fn for the box and every box nested in it, parents before
+// their children.
+func (b *Box) Walk(fn func(*Box)) {
+	if b == nil {
+		return
+	}
+	fn(b)
+	for _, child := range b.Children {
+		child.Walk(fn)
+	}
+}
+
+// translate moves a box and the boxes nested in it.
+func (b *Box) translate(dx, dy int) {
+	b.Walk(func(b *Box) {
+		b.Rect.X += dx
+		b.Rect.Y += dy
+	})
+}
+
+// Engine computes the geometry of components: it measures them, then
+// arranges them in the space they are given.
+type Engine struct {
+	measurer Measurer
+}
+
+// NewEngine creates a new layout engine measuring components with m. A nil
+// measurer estimates sizes from the text of components.
+func NewEngine(m Measurer) *Engine {
+	if m == nil {
+		m = NewTextMeasurer()
+	}
+	return &Engine{measurer: m}
+}
+
+// Measure returns the range of sizes a component can be shown at.
+func (e *Engine) Measure(c Component) SizeHint {
+	if c == nil {
+		return SizeHint{}
+	}
+	if arranger, ok := c.(Arranger); ok {
+		return arranger.MeasureLayout(e)
+	}
+	return e.measurer.Measure(c)
+}
+
+// Arrange places a component and, for layouts, its children inside bounds.
+func (e *Engine) Arrange(c Component, bounds Rect) *Box {
+	box := &Box{Component: c, Rect: bounds}
+	if arranger, ok := c.(Arranger); ok {
+		box.Children = arranger.ArrangeLayout(e, bounds)
+	}
+	return box
+}
+
+// Layout places a component in an area of the given size, such as a window.
+func (e *Engine) Layout(c Component, width, height int) *Box {
+	return e.Arrange(c, Rect{Width: width, Height: height})
+}
+
+// TextMeasurer estimates the size of components from their text, assuming
+// every character has the same width.
+type TextMeasurer struct {
+	// FontSize is used for components without a font size of their own.
+	FontSize int
+	// CharWidth is the width of a character, relative to the font size.
+	CharWidth float64
+	// LineHeight is the height of a line, relative to the font size.
+	LineHeight float64
+}
+
+// NewTextMeasurer creates a new text measurer with proportions close to
+// common sans-serif fonts.
+func NewTextMeasurer() *TextMeasurer {
+	return &TextMeasurer{
+		FontSize:   14,
+		CharWidth:  0.6,
+		LineHeight: 1.4,
+	}
+}
+
+// Measure returns the size of the component's text. The minimum width fits
+// the longest word. Components with a size of their own, such as buttons
+// and images, use it instead.
+func (m *TextMeasurer) Measure(c Component) SizeHint {
+	fontSize := m.FontSize
+	if sized, ok := c.(interface{ FontSize() int }); ok && sized.FontSize() > 0 {
+		fontSize = sized.FontSize()
+	}
+	charWidth := float64(fontSize) * m.CharWidth
+	lineHeight := int(math.Ceil(float64(fontSize) * m.LineHeight))
+
+	lines := strings.Split(componentText(c), "\n")
+	var longestLine, longestWord int
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line); n > longestLine {
+			longestLine = n
+		}
+		for _, word := range strings.Fields(line) {
+			if n := utf8.RuneCountInString(word); n > longestWord {
+				longestWord = n
+			}
+		}
+	}
+
+	hint := SizeHint{
+		Min: Size{
+			Width:  int(math.Ceil(float64(longestWord) * charWidth)),
+			Height: len(lines) * lineHeight,
+		},
+		Preferred: Size{
+			Width:  int(math.Ceil(float64(longestLine) * charWidth)),
+			Height: len(lines) * lineHeight,
+		},
+	}
+
+	switch sized := c.(type) {
+	case interface{ Size() (int, int) }:
+		width, height := sized.Size()
+		if width > 0 {
+			hint.Min.Width, hint.Preferred.Width = width, width
+		}
+		if height > 0 {
+			hint.Min.Height, hint.Preferred.Height = height, height
+		}
+	case interface{ Size() int }:
+		// Spacers are as wide as they are tall
+		size := Size{Width: sized.Size(), Height: sized.Size()}
+		hint = SizeHint{Min: size, Preferred: size}
+	}
+	return hint
+}
+
+// componentText returns the text a component shows: its text or label if it
+// has one, or else its string rendering.
+func componentText(c Component) string {
+	switch c := c.(type) {
+	case interface{ Text() string }:
+		return c.Text()
+	case interface{ Label() string }:
+		return c.Label()
+	case interface{ Size() int }:
+		return ""
+	}
+	return c.Render()
+}
+
+// Alignment sets where a component is placed across a row or column that is
+// wider than the component.
+type Alignment int
+
+const (
+	// AlignStretch stretches the component across the row or column.
+	AlignStretch Alignment = iota
+	// AlignStart places the component at the top or left.
+	AlignStart
+	// AlignCenter centers the component.
+	AlignCenter
+	// AlignEnd places the component at the bottom or right.
+	AlignEnd
+)
+
+// Justify sets how the components of a row or column share the space left
+// along it once they have grown.
+type Justify int
+
+const (
+	// JustifyStart packs the components at the top or left.
+	JustifyStart Justify = iota
+	// JustifyCenter packs the components in the middle.
+	JustifyCenter
+	// JustifyEnd packs the components at the bottom or right.
+	JustifyEnd
+	// JustifySpaceBetween puts the first and last component at the ends and
+	// spreads the others evenly between them.
+	JustifySpaceBetween
+	// JustifySpaceAround gives every component the same space on both sides.
+	JustifySpaceAround
+	// JustifySpaceEvenly makes the space before the first component, between
+	// the components and after the last one the same.
+	JustifySpaceEvenly
+)
+
+// BasisAuto is the flex basis of components that start from their preferred
+// size before growing or shrinking.
+const BasisAuto = -1
+
+// flexItem is a component placed in a row or column, with how it grows and
+// shrinks along it.
+type flexItem struct {
+	component Component
+	hint      SizeHint
+	grow      float64
+	shrink    float64
+	basis     int
+	align     Alignment
+}
+
+// base returns the size of the item along the axis before it grows or
+// shrinks. It is never smaller than the minimum size.
+func (item flexItem) base(a axis) int {
+	if item.basis >= 0 {
+		return maxInt(item.basis, a.main(item.hint.Min))
+	}
+	return a.main(item.hint.Preferred)
+}
+
+// newFlexItems measures components for a row or column. Items keep their
+// preferred size, shrink down to their minimum size if space is short and
+// stretch across the row or column.
+func newFlexItems(e *Engine, components []Component) []flexItem {
+	items := make([]flexItem, 0, len(components))
+	for _, c := range components {
+		if c != nil {
+			item := sizedItem(e.Measure(c))
+			item.component = c
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// sizedItem returns an item of the given size range that keeps its
+// preferred size and only shrinks when space is short, for measuring parts
+// of layouts that aren't components.
+func sizedItem(hint SizeHint) flexItem {
+	return flexItem{hint: hint, shrink: 1, basis: BasisAuto}
+}
+
+// axis reads and writes the main and cross axis of sizes and rectangles,
+// so rows and columns can share one algorithm.
+type axis bool
+
+const (
+	horizontalAxis axis = true
+	verticalAxis   axis = false
+)
+
+// main returns the extent of a size along the axis.
+func (a axis) main(s Size) int {
+	if a == horizontalAxis {
+		return s.Width
+	}
+	return s.Height
+}
+
+// cross returns the extent of a size across the axis.
+func (a axis) cross(s Size) int {
+	if a == horizontalAxis {
+		return s.Height
+	}
+	return s.Width
+}
+
+// size builds a size from its extents along and across the axis.
+func (a axis) size(main, cross int) Size {
+	if a == horizontalAxis {
+		return Size{Width: main, Height: cross}
+	}
+	return Size{Width: cross, Height: main}
+}
+
+// rect builds a rectangle from its position and extents along and across
+// the axis, relative to the origin of bounds.
+func (a axis) rect(bounds Rect, main, cross, mainSize, crossSize int) Rect {
+	if a == horizontalAxis {
+		return Rect{X: bounds.X + main, Y: bounds.Y + cross, Width: mainSize, Height: crossSize}
+	}
+	return Rect{X: bounds.X + cross, Y: bounds.Y + main, Width: crossSize, Height: mainSize}
+}
+
+// flexBox places items in a row or column, wrapping them onto several
+// lines if needed.
+type flexBox struct {
+	axis    axis
+	spacing int
+	justify Justify
+	wrap    bool
+}
+
+// measureLine returns the size range of items placed one after another
+// along an axis with spacing between them.
+func measureLine(items []flexItem, a axis, spacing int) SizeHint {
+	return flexBox{axis: a, spacing: spacing}.measure(items)
+}
+
+// arrangeLine places items one after another along an axis inside bounds.
+func arrangeLine(e *Engine, items []flexItem, a axis, bounds Rect, spacing int) []*Box {
+	return flexBox{axis: a, spacing: spacing}.arrange(e, items, bounds)
+}
+
+// measure returns the size range of the items. Wrapping items fit in the
+// width of the widest item, one per line.
+func (f flexBox) measure(items []flexItem) SizeHint {
+	a := f.axis
+	var minMain, prefMain, minCross, prefCross int
+	for i, item := range items {
+		if i > 0 {
+			prefMain += f.spacing
+			if !f.wrap {
+				minMain += f.spacing
+			}
+		}
+		if f.wrap {
+			minMain = maxInt(minMain, a.main(item.hint.Min))
+		} else {
+			minMain += a.main(item.hint.Min)
+		}
+		prefMain += item.base(a)
+		minCross = maxInt(minCross, a.cross(item.hint.Min))
+		prefCross = maxInt(prefCross, a.cross(item.hint.Preferred))
+	}
+	return SizeHint{Min: a.size(minMain, minCross), Preferred: a.size(prefMain, prefCross)}
+}
+
+// lines splits the items into the lines they are placed on. Without
+// wrapping, all items are on one line.
+func (f flexBox) lines(items []flexItem, available int) [][]flexItem {
+	if !f.wrap {
+		return [][]flexItem{items}
+	}
+	var lines [][]flexItem
+	start, used := 0, 0
+	for i, item := range items {
+		size := item.base(f.axis)
+		if i > start && used+f.spacing+size > available {
+			lines = append(lines, items[start:i])
+			start, used = i, 0
+		}
+		if i > start {
+			used += f.spacing
+		}
+		used += size
+	}
+	return append(lines, items[start:])
+}
+
+// place returns the area of every item inside bounds. A single line fills
+// bounds across the axis, wrapped lines are as thick as their thickest item.
+func (f flexBox) place(items []flexItem, bounds Rect) []Rect {
+	a := f.axis
+	available, crossAvailable := a.main(bounds.Size()), a.cross(bounds.Size())
+
+	rects := make([]Rect, 0, len(items))
+	cross := 0
+	for _, line := range f.lines(items, available) {
+		thickness := crossAvailable
+		if f.wrap {
+			thickness = 0
+			for _, item := range line {
+				thickness = maxInt(thickness, a.cross(item.hint.Preferred))
+			}
+		}
+
+		sizes := flexSizes(line, a, available, f.spacing)
+		position, gap := f.spread(available, sizes)
+		for i, item := range line {
+			size := thickness
+			if item.align != AlignStretch {
+				size = minInt(a.cross(item.hint.Preferred), thickness)
+			}
+			offset := 0
+			switch item.align {
+			case AlignCenter:
+				offset = (thickness - size) / 2
+			case AlignEnd:
+				offset = thickness - size
+			}
+			rects = append(rects, a.rect(bounds, position, cross+offset, sizes[i], size))
+			position += sizes[i] + gap
+		}
+		cross += thickness + f.spacing
+	}
+	return rects
+}
+
+// spread returns where the first item of a line starts and the space
+// between items, sharing the space left on the line as justified.
+func (f flexBox) spread(available int, sizes []int) (start, gap int) {
+	free := available - sumTracks(sizes, f.spacing)
+	if free <= 0 || len(sizes) == 0 {
+		return 0, f.spacing
+	}
+	switch f.justify {
+	case JustifyCenter:
+		return free / 2, f.spacing
+	case JustifyEnd:
+		return free, f.spacing
+	case JustifySpaceBetween:
+		if len(sizes) == 1 {
+			return 0, f.spacing
+		}
+		return 0, f.spacing + free/(len(sizes)-1)
+	case JustifySpaceAround:
+		around := free / len(sizes)
+		return around / 2, f.spacing + around
+	case JustifySpaceEvenly:
+		even := free / (len(sizes) + 1)
+		return even, f.spacing + even
+	}
+	return 0, f.spacing
+}
+
+// arrange places the items, and their children for layouts, inside bounds.
+func (f flexBox) arrange(e *Engine, items []flexItem, bounds Rect) []*Box {
+	rects := f.place(items, bounds)
+	boxes := make([]*Box, len(items))
+	for i, item := range items {
+		boxes[i] = e.Arrange(item.component, rects[i])
+	}
+	return boxes
+}
+
+// flexSizes returns the size of every item along the axis. Items start at
+// their basis; extra space goes to the items that grow, in
+// proportion to their grow factor, and missing space is taken from the items
+// that shrink, in proportion to their shrink factor and size, without going
+// below their minimum size.
+func flexSizes(items []flexItem, a axis, available, spacing int) []int {
+	sizes := make([]int, len(items))
+	used := spacing * maxInt(len(items)-1, 0)
+	for i, item := range items {
+		sizes[i] = item.base(a)
+		used += sizes[i]
+	}
+
+	free := available - used
+	if free > 0 {
+		var grow float64
+		for _, item := range items {
+			grow += item.grow
+		}
+		if grow > 0 {
+			distribute(sizes, free, func(i int) float64 { return items[i].grow / grow })
+		}
+		return sizes
+	}
+
+	// Shrinking an item to its minimum frees less than its share, so the
+	// rest is shared again between the items that can still shrink
+	frozen := make([]bool, len(items))
+	for free < 0 {
+		var weight float64
+		for i, item := range items {
+			if !frozen[i] {
+				weight += item.shrink * float64(sizes[i])
+			}
+		}
+		if weight == 0 {
+			break
+		}
+
+		shares := make([]int, len(items))
+		distribute(shares, -free, func(i int) float64 {
+			if frozen[i] {
+				return 0
+			}
+			return items[i].shrink * float64(sizes[i]) / weight
+		})
+		free = 0
+		for i, item := range items {
+			if shares[i] == 0 {
+				continue
+			}
+			min := a.main(item.hint.Min)
+			if sizes[i]-shares[i] <= min {
+				free -= shares[i] - (sizes[i] - min)
+				sizes[i] = min
+				frozen[i] = true
+			} else {
+				sizes[i] -= shares[i]
+			}
+		}
+	}
+	return sizes
+}
+
+// distribute adds space to sizes by the given shares, giving the pixels
+// lost to rounding to the last item that gets a share.
+func distribute(sizes []int, space int, share func(i int) float64) {
+	given, last := 0, -1
+	for i := range sizes {
+		if s := share(i); s > 0 {
+			extra := int(float64(space) * s)
+			sizes[i] += extra
+			given += extra
+			last = i
+		}
+	}
+	if last >= 0 {
+		sizes[last] += space - given
+	}
+}
+
+// maxInt returns the larger of two values.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// minInt returns the smaller of two values.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// nonNegative returns n, or zero if n is negative.
+func nonNegative(n int) int {
+	return maxInt(n, 0)
+}
@@ -0,0 +1,731 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+// item is a component with a fixed size hint.
+type item struct {
+	name string
+	hint SizeHint
+}
+
+func (i *item) Render() string {
+	return i.name
+}
+
+// newItem creates an item with the given minimum and preferred size.
+func newItem(name string, minWidth, minHeight, prefWidth, prefHeight int) *item {
+	return &item{name: name, hint: SizeHint{
+		Min:       Size{Width: minWidth, Height: minHeight},
+		Preferred: Size{Width: prefWidth, Height: prefHeight},
+	}}
+}
+
+// itemMeasurer measures items by their size hint.
+type itemMeasurer struct{}
+
+func (itemMeasurer) Measure(c Component) SizeHint {
+	return c.(*item).hint
+}
+
+// newTestEngine creates an engine measuring items by their size hint.
+func newTestEngine() *Engine {
+	return NewEngine(itemMeasurer{})
+}
+
+// row creates a horizontal flex layout holding the items.
+func row(items ...Component) *FlexLayout {
+	l := NewFlexLayout()
+	l.SetDirection(Horizontal)
+	l.Add(items...)
+	return l
+}
+
+// childRects returns the areas of the children of a box.
+func childRects(box *Box) []Rect {
+	rects := make([]Rect, len(box.Children))
+	for i, child := range box.Children {
+		rects[i] = child.Rect
+	}
+	return rects
+}
+
+func TestMeasure(t *testing.T) {
+	small := func() *item { return newItem("small", 10, 10, 40, 20) }
+	large := func() *item { return newItem("large", 20, 10, 30, 30) }
+
+	tests := []struct {
+		name   string
+		layout func() Component
+		want   SizeHint
+	}{
+		{
+			name:   "nil",
+			layout: func() Component { return nil },
+			want:   SizeHint{},
+		},
+		{
+			name: "stack with spacing and padding",
+			layout: func() Component {
+				l := NewStackLayout()
+				l.Add(small(), large())
+				l.SetSpacing(5)
+				l.SetPadding(2)
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 24, Height: 29}, Preferred: Size{Width: 44, Height: 59}},
+		},
+		{
+			name: "row",
+			layout: func() Component {
+				l := row(small(), large())
+				l.SetSpacing(5)
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 35, Height: 10}, Preferred: Size{Width: 75, Height: 30}},
+		},
+		{
+			name: "wrapping row fits the widest item",
+			layout: func() Component {
+				l := row(small(), large())
+				l.SetSpacing(5)
+				l.SetWrap(true)
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 20, Height: 10}, Preferred: Size{Width: 75, Height: 30}},
+		},
+		{
+			name: "flex basis",
+			layout: func() Component {
+				l := row(small(), large())
+				l.SetFlex(l.Components()[0], FlexChild{Shrink: 1, Basis: 60})
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 30, Height: 10}, Preferred: Size{Width: 90, Height: 30}},
+		},
+		{
+			name: "nested layouts",
+			layout: func() Component {
+				l := NewStackLayout()
+				l.Add(row(small(), large()), large())
+				l.SetPadding(1)
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 32, Height: 22}, Preferred: Size{Width: 72, Height: 62}},
+		},
+		{
+			name: "grid with a spanning cell",
+			layout: func() Component {
+				l := NewGridLayout(2)
+				l.Add(small(), small())
+				l.AddWithSpan(large(), 1, 2)
+				l.SetGaps(4, 6)
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 26, Height: 24}, Preferred: Size{Width: 86, Height: 54}},
+		},
+		{
+			name: "fixed grid columns",
+			layout: func() Component {
+				l := NewGridLayout(2)
+				l.Add(small(), small())
+				l.SetColumnWidths(Fixed(50))
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 60, Height: 10}, Preferred: Size{Width: 90, Height: 20}},
+		},
+		{
+			name: "border layout",
+			layout: func() Component {
+				return NewBorderLayout(small(), nil, large(), nil, small())
+			},
+			want: SizeHint{Min: Size{Width: 30, Height: 20}, Preferred: Size{Width: 70, Height: 50}},
+		},
+		{
+			name: "split pane",
+			layout: func() Component {
+				l := NewSplitPane(Horizontal, small(), large())
+				l.SetMinSizes(50, 0)
+				return l
+			},
+			want: SizeHint{Min: Size{Width: 50 + DividerSize + 20, Height: 10}, Preferred: Size{Width: 50 + DividerSize + 30, Height: 30}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEngine()
+			c := tt.layout()
+			hint := e.Measure(c)
+			if hint != tt.want {
+				t.Fatalf("Measure() = %+v, want %+v", hint, tt.want)
+			}
+			if c == nil {
+				return
+			}
+
+			// Arranged at its preferred size, every item gets at least its
+			// preferred size
+			e.Layout(c, hint.Preferred.Width, hint.Preferred.Height).Walk(func(b *Box) {
+				if it, ok := b.Component.(*item); ok {
+					if b.Rect.Width < it.hint.Preferred.Width || b.Rect.Height < it.hint.Preferred.Height {
+						t.Errorf("%s arranged in %+v, smaller than its preferred size %+v", it.name, b.Rect, it.hint.Preferred)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestArrangeFlex(t *testing.T) {
+	// Items prefer to be 20 wide unless set otherwise, and can shrink to
+	// nothing
+	items := func(widths ...int) []Component {
+		components := make([]Component, len(widths))
+		for i, width := range widths {
+			components[i] = newItem("item", 0, 5, width, 10)
+		}
+		return components
+	}
+
+	tests := []struct {
+		name   string
+		layout func() *FlexLayout
+		width  int
+		height int
+		want   []Rect
+	}{
+		{
+			name:   "preferred sizes",
+			layout: func() *FlexLayout { return row(items(20, 30)...) },
+			width:  100, height: 10,
+			want: []Rect{{0, 0, 20, 10}, {20, 0, 30, 10}},
+		},
+		{
+			name: "spacing and padding",
+			layout: func() *FlexLayout {
+				l := row(items(20, 30)...)
+				l.SetSpacing(5)
+				l.SetPadding(3)
+				return l
+			},
+			width: 100, height: 16,
+			want: []Rect{{3, 3, 20, 10}, {28, 3, 30, 10}},
+		},
+		{
+			name: "column",
+			layout: func() *FlexLayout {
+				l := NewFlexLayout()
+				l.Add(items(20, 30)...)
+				return l
+			},
+			width: 50, height: 100,
+			want: []Rect{{0, 0, 50, 10}, {0, 10, 50, 10}},
+		},
+		{
+			name: "grow by factor",
+			layout: func() *FlexLayout {
+				l := row(items(10, 10)...)
+				l.SetGrow(l.Components()[0], 1)
+				l.SetGrow(l.Components()[1], 3)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 30, 10}, {30, 0, 70, 10}},
+		},
+		{
+			name: "grow gives rounding to the last growing item",
+			layout: func() *FlexLayout {
+				l := row(items(0, 0, 0, 0)...)
+				for _, c := range l.Components()[:3] {
+					l.SetGrow(c, 1)
+				}
+				return l
+			},
+			width: 10, height: 10,
+			want: []Rect{{0, 0, 3, 10}, {3, 0, 3, 10}, {6, 0, 4, 10}, {10, 0, 0, 10}},
+		},
+		{
+			name:   "shrink in proportion to size",
+			layout: func() *FlexLayout { return row(items(100, 50)...) },
+			width:  120, height: 10,
+			want: []Rect{{0, 0, 80, 10}, {80, 0, 40, 10}},
+		},
+		{
+			name: "shrink counts spacing",
+			layout: func() *FlexLayout {
+				l := row(items(50, 50)...)
+				l.SetSpacing(10)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 45, 10}, {55, 0, 45, 10}},
+		},
+		{
+			name: "shrink stops at the minimum and the rest shrink more",
+			layout: func() *FlexLayout {
+				l := row(newItem("firm", 90, 5, 100, 10), newItem("soft", 0, 5, 100, 10))
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 90, 10}, {90, 0, 10, 10}},
+		},
+		{
+			name: "minimum sizes overflow",
+			layout: func() *FlexLayout {
+				return row(newItem("a", 50, 5, 50, 10), newItem("b", 50, 5, 50, 10))
+			},
+			width: 60, height: 10,
+			want: []Rect{{0, 0, 50, 10}, {50, 0, 50, 10}},
+		},
+		{
+			name: "items without shrink keep their size",
+			layout: func() *FlexLayout {
+				l := row(items(100, 100)...)
+				l.SetFlex(l.Components()[0], FlexChild{Basis: BasisAuto})
+				return l
+			},
+			width: 150, height: 10,
+			want: []Rect{{0, 0, 100, 10}, {100, 0, 50, 10}},
+		},
+		{
+			name: "basis",
+			layout: func() *FlexLayout {
+				l := row(items(10, 20)...)
+				l.SetFlex(l.Components()[0], FlexChild{Shrink: 1, Basis: 40})
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 40, 10}, {40, 0, 20, 10}},
+		},
+		{
+			name: "justify center",
+			layout: func() *FlexLayout {
+				l := row(items(20, 20)...)
+				l.SetJustify(JustifyCenter)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{30, 0, 20, 10}, {50, 0, 20, 10}},
+		},
+		{
+			name: "justify end",
+			layout: func() *FlexLayout {
+				l := row(items(20, 20)...)
+				l.SetJustify(JustifyEnd)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{60, 0, 20, 10}, {80, 0, 20, 10}},
+		},
+		{
+			name: "justify space between",
+			layout: func() *FlexLayout {
+				l := row(items(20, 20, 20)...)
+				l.SetJustify(JustifySpaceBetween)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 20, 10}, {40, 0, 20, 10}, {80, 0, 20, 10}},
+		},
+		{
+			name: "justify space between with spacing",
+			layout: func() *FlexLayout {
+				l := row(items(20, 20, 20)...)
+				l.SetJustify(JustifySpaceBetween)
+				l.SetSpacing(10)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 20, 10}, {40, 0, 20, 10}, {80, 0, 20, 10}},
+		},
+		{
+			name: "justify space between a single item",
+			layout: func() *FlexLayout {
+				l := row(items(20)...)
+				l.SetJustify(JustifySpaceBetween)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 20, 10}},
+		},
+		{
+			name: "justify space around",
+			layout: func() *FlexLayout {
+				l := row(items(20, 20)...)
+				l.SetJustify(JustifySpaceAround)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{15, 0, 20, 10}, {65, 0, 20, 10}},
+		},
+		{
+			name: "justify space evenly",
+			layout: func() *FlexLayout {
+				l := row(items(20, 20, 20)...)
+				l.SetJustify(JustifySpaceEvenly)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{10, 0, 20, 10}, {40, 0, 20, 10}, {70, 0, 20, 10}},
+		},
+		{
+			name: "justify ignored once items grow",
+			layout: func() *FlexLayout {
+				l := row(items(20, 20)...)
+				l.SetJustify(JustifyEnd)
+				l.SetGrow(l.Components()[1], 1)
+				return l
+			},
+			width: 100, height: 10,
+			want: []Rect{{0, 0, 20, 10}, {20, 0, 80, 10}},
+		},
+		{
+			name:   "align stretch",
+			layout: func() *FlexLayout { return row(items(20)...) },
+			width:  100, height: 30,
+			want: []Rect{{0, 0, 20, 30}},
+		},
+		{
+			name: "align start",
+			layout: func() *FlexLayout {
+				l := row(items(20)...)
+				l.SetAlignment(AlignStart)
+				return l
+			},
+			width: 100, height: 30,
+			want: []Rect{{0, 0, 20, 10}},
+		},
+		{
+			name: "align center",
+			layout: func() *FlexLayout {
+				l := row(items(20)...)
+				l.SetAlignment(AlignCenter)
+				return l
+			},
+			width: 100, height: 30,
+			want: []Rect{{0, 10, 20, 10}},
+		},
+		{
+			name: "align end",
+			layout: func() *FlexLayout {
+				l := row(items(20)...)
+				l.SetAlignment(AlignEnd)
+				return l
+			},
+			width: 100, height: 30,
+			want: []Rect{{0, 20, 20, 10}},
+		},
+		{
+			name: "align shrinks items taller than the line",
+			layout: func() *FlexLayout {
+				l := row(items(20)...)
+				l.SetAlignment(AlignCenter)
+				return l
+			},
+			width: 100, height: 6,
+			want: []Rect{{0, 0, 20, 6}},
+		},
+		{
+			name: "wrap breaks lines that are too long",
+			layout: func() *FlexLayout {
+				l := row(items(40, 40, 40)...)
+				l.SetWrap(true)
+				l.SetSpacing(10)
+				return l
+			},
+			width: 100, height: 100,
+			want: []Rect{{0, 0, 40, 10}, {50, 0, 40, 10}, {0, 20, 40, 10}},
+		},
+		{
+			name: "wrap puts items wider than the line on their own",
+			layout: func() *FlexLayout {
+				l := row(items(20, 150, 20)...)
+				l.SetWrap(true)
+				return l
+			},
+			width: 100, height: 100,
+			want: []Rect{{0, 0, 20, 10}, {0, 10, 100, 10}, {0, 20, 20, 10}},
+		},
+		{
+			name: "wrap justifies every line",
+			layout: func() *FlexLayout {
+				l := row(items(40, 40, 40)...)
+				l.SetWrap(true)
+				l.SetSpacing(10)
+				l.SetJustify(JustifyCenter)
+				return l
+			},
+			width: 100, height: 100,
+			want: []Rect{{5, 0, 40, 10}, {55, 0, 40, 10}, {30, 20, 40, 10}},
+		},
+		{
+			name: "wrap grows items within their line",
+			layout: func() *FlexLayout {
+				l := row(items(40, 40, 40)...)
+				l.SetWrap(true)
+				l.SetGrow(l.Components()[2], 1)
+				return l
+			},
+			width: 100, height: 100,
+			want: []Rect{{0, 0, 40, 10}, {40, 0, 40, 10}, {0, 10, 100, 10}},
+		},
+		{
+			name: "wrap aligns items in lines as thick as their thickest item",
+			layout: func() *FlexLayout {
+				l := row(newItem("tall", 0, 5, 40, 30), newItem("short", 0, 5, 40, 10), newItem("next", 0, 5, 40, 10))
+				l.SetWrap(true)
+				l.SetAlignment(AlignEnd)
+				return l
+			},
+			width: 100, height: 100,
+			want: []Rect{{0, 0, 40, 30}, {40, 20, 40, 10}, {0, 30, 40, 10}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := newTestEngine().Layout(tt.layout(), tt.width, tt.height)
+			if got := childRects(box); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("children arranged in %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrangeGrid(t *testing.T) {
+	items := func(n int) []Component {
+		components := make([]Component, n)
+		for i := range components {
+			components[i] = newItem("cell", 0, 5, 20, 10)
+		}
+		return components
+	}
+
+	tests := []struct {
+		name   string
+		layout func() *GridLayout
+		width  int
+		want   []Rect
+	}{
+		{
+			name: "equal columns",
+			layout: func() *GridLayout {
+				l := NewGridLayout(2)
+				l.Add(items(3)...)
+				return l
+			},
+			width: 100,
+			want:  []Rect{{0, 0, 50, 10}, {50, 0, 50, 10}, {0, 10, 50, 10}},
+		},
+		{
+			name: "fixed and fraction columns with gaps and a span",
+			layout: func() *GridLayout {
+				l := NewGridLayout(2)
+				l.Add(items(2)...)
+				l.AddWithSpan(newItem("wide", 0, 5, 20, 10), 1, 2)
+				l.SetColumnWidths(Fixed(30))
+				l.SetGaps(5, 10)
+				return l
+			},
+			width: 100,
+			want:  []Rect{{0, 0, 30, 10}, {40, 0, 60, 10}, {0, 15, 100, 10}},
+		},
+		{
+			name: "fraction shares",
+			layout: func() *GridLayout {
+				l := NewGridLayout(2)
+				l.Add(items(2)...)
+				l.SetColumnWidths(Fraction(1), Fraction(3))
+				return l
+			},
+			width: 100,
+			want:  []Rect{{0, 0, 25, 10}, {25, 0, 75, 10}},
+		},
+		{
+			name: "fraction columns keep their content",
+			layout: func() *GridLayout {
+				l := NewGridLayout(2)
+				l.Add(newItem("wide", 0, 5, 60, 10), newItem("cell", 0, 5, 20, 10))
+				l.SetColumnWidths(Fraction(1), Fraction(3))
+				return l
+			},
+			width: 100,
+			want:  []Rect{{0, 0, 60, 10}, {60, 0, 40, 10}},
+		},
+		{
+			name: "row span",
+			layout: func() *GridLayout {
+				l := NewGridLayout(2)
+				l.AddWithSpan(newItem("tall", 0, 5, 20, 10), 2, 1)
+				l.Add(items(3)...)
+				return l
+			},
+			width: 100,
+			want:  []Rect{{0, 0, 50, 20}, {50, 0, 50, 10}, {50, 10, 50, 10}, {0, 20, 50, 10}},
+		},
+		{
+			name: "auto columns",
+			layout: func() *GridLayout {
+				l := NewAutoGridLayout(40)
+				l.Add(items(3)...)
+				l.SetGaps(0, 10)
+				return l
+			},
+			width: 100,
+			want:  []Rect{{0, 0, 45, 10}, {55, 0, 45, 10}, {0, 10, 45, 10}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := newTestEngine().Layout(tt.layout(), tt.width, 100)
+			if got := childRects(box); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("cells arranged in %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceGrid(t *testing.T) {
+	tests := []struct {
+		name    string
+		spans   []GridSpan
+		columns int
+		want    []GridCell
+	}{
+		{
+			name:    "fills rows from the left",
+			spans:   []GridSpan{{1, 1}, {1, 1}, {1, 1}, {1, 1}},
+			columns: 3,
+			want:    []GridCell{{0, 0, 1, 1}, {0, 1, 1, 1}, {0, 2, 1, 1}, {1, 0, 1, 1}},
+		},
+		{
+			name:    "moves cells that don't fit to the next row",
+			spans:   []GridSpan{{1, 1}, {1, 1}, {1, 2}},
+			columns: 3,
+			want:    []GridCell{{0, 0, 1, 1}, {0, 1, 1, 1}, {1, 0, 1, 2}},
+		},
+		{
+			name:    "skips cells taken by row spans",
+			spans:   []GridSpan{{2, 1}, {1, 1}, {1, 1}, {1, 1}},
+			columns: 2,
+			want:    []GridCell{{0, 0, 2, 1}, {0, 1, 1, 1}, {1, 1, 1, 1}, {2, 0, 1, 1}},
+		},
+		{
+			name:    "never goes back to earlier holes",
+			spans:   []GridSpan{{1, 1}, {1, 2}, {1, 1}},
+			columns: 2,
+			want:    []GridCell{{0, 0, 1, 1}, {1, 0, 1, 2}, {2, 0, 1, 1}},
+		},
+		{
+			name:    "narrows cells wider than the grid",
+			spans:   []GridSpan{{1, 5}},
+			columns: 3,
+			want:    []GridCell{{0, 0, 1, 3}},
+		},
+		{
+			name:    "spans at least one row and column",
+			spans:   []GridSpan{{0, 0}, {-1, 1}},
+			columns: 2,
+			want:    []GridCell{{0, 0, 1, 1}, {0, 1, 1, 1}},
+		},
+		{
+			name:    "at least one column",
+			spans:   []GridSpan{{1, 1}, {1, 1}},
+			columns: 0,
+			want:    []GridCell{{0, 0, 1, 1}, {1, 0, 1, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlaceGrid(tt.spans, tt.columns); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("PlaceGrid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoColumnCount(t *testing.T) {
+	tests := []struct {
+		name                             string
+		width, minColumnWidth, gap, want int
+	}{
+		{"exact fit", 300, 100, 0, 3},
+		{"one pixel short", 299, 100, 0, 2},
+		{"gaps", 320, 100, 10, 3},
+		{"gaps one pixel short", 319, 100, 10, 2},
+		{"narrower than a column", 50, 100, 0, 1},
+		{"no minimum width", 100, 0, 10, 1},
+		{"negative gap", 300, 100, -10, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AutoColumnCount(tt.width, tt.minColumnWidth, tt.gap); got != tt.want {
+				t.Fatalf("AutoColumnCount(%d, %d, %d) = %d, want %d", tt.width, tt.minColumnWidth, tt.gap, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHitTest(t *testing.T) {
+	a := newItem("a", 0, 10, 20, 10)
+	b := newItem("b", 0, 10, 20, 10)
+	inner := row(a, b)
+	inner.SetSpacing(10)
+	outer := NewStackLayout()
+	outer.Add(inner)
+	outer.SetPadding(10)
+
+	// The row is at (10, 10) and 80 by 10, a and b are in it at 10 and 40
+	box := newTestEngine().Layout(outer, 100, 50)
+
+	tests := []struct {
+		name string
+		x, y int
+		want Component
+	}{
+		{"padding", 0, 0, outer},
+		{"top left corner of an item", 10, 10, a},
+		{"bottom right corner of an item", 29, 19, a},
+		{"spacing between items", 30, 10, inner},
+		{"next item", 40, 15, b},
+		{"rest of the row", 60, 10, inner},
+		{"below the row", 10, 20, outer},
+		{"bottom right corner", 99, 49, outer},
+		{"right edge", 100, 10, nil},
+		{"bottom edge", 10, 50, nil},
+		{"negative", -1, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit := box.HitTest(tt.x, tt.y)
+			var got Component
+			if hit != nil {
+				got = hit.Component
+			}
+			if got != tt.want {
+				t.Fatalf("HitTest(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+
+	if found := box.Find(b); found == nil || found.Rect != (Rect{40, 10, 20, 10}) {
+		t.Fatalf("Find(b) = %+v, want the box at (40, 10)", found)
+	}
+	if found := box.Find(outer); found != box {
+		t.Fatalf("Find(outer) = %+v, want the root box", found)
+	}
+	if found := box.Find(newItem("elsewhere", 0, 0, 0, 0)); found != nil {
+		t.Fatalf("Find() = %+v for a component that isn't laid out", found)
+	}
+	var none *Box
+	if none.HitTest(0, 0) != nil || none.Find(a) != nil {
+		t.Fatal("a nil box hit or found something")
+	}
+}
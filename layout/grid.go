@@ -0,0 +1,532 @@
+package layout
+
+import (
+	"strings"
+)
+
+// ColumnKind identifies how the width of a grid column is computed.
+type ColumnKind int
+
+const (
+	// AutoColumn is as wide as its widest cell.
+	AutoColumn ColumnKind = iota
+	// FixedColumn has a fixed width in pixels.
+	FixedColumn
+	// FractionColumn shares the space left by the other columns with the
+	// other fraction columns, in proportion to its fraction.
+	FractionColumn
+)
+
+// ColumnWidth specifies the width of a grid column.
+type ColumnWidth struct {
+	// Kind is how the width is computed.
+	Kind ColumnKind
+	// Value is the width in pixels for fixed columns, or the share of the
+	// remaining space for fraction columns.
+	Value float64
+}
+
+// Fixed returns the width of a column that is always the given number of pixels wide.
+func Fixed(pixels int) ColumnWidth {
+	return ColumnWidth{Kind: FixedColumn, Value: float64(pixels)}
+}
+
+// Fraction returns the width of a column that takes the given share of the remaining space.
+func Fraction(share float64) ColumnWidth {
+	return ColumnWidth{Kind: FractionColumn, Value: share}
+}
+
+// Auto returns the width of a column that is as wide as its content.
+func Auto() ColumnWidth {
+	return ColumnWidth{Kind: AutoColumn}
+}
+
+// GridSpan is the number of rows and columns a grid cell spans.
+type GridSpan struct {
+	Rows    int
+	Columns int
+}
+
+// GridCell is the position of a component on a grid.
+type GridCell struct {
+	Row        int
+	Column     int
+	RowSpan    int
+	ColumnSpan int
+}
+
+// GridLayout arranges components in rows and columns. Components fill the
+// rows from left to right, and may span several rows or columns.
+type GridLayout struct {
+	BaseLayout
+	columns        int
+	minColumnWidth int
+	columnWidths   []ColumnWidth
+	spans          map[Component]GridSpan
+	rowGap         int
+	columnGap      int
+	gapsSet        bool
+}
+
+// NewGridLayout creates a new grid layout with the given number of columns.
+func NewGridLayout(columns int) *GridLayout {
+	if columns < 1 {
+		columns = 1
+	}
+	l := &GridLayout{
+		BaseLayout: BaseLayout{
+			components: make([]Component, 0),
+		},
+		columns: columns,
+		spans:   make(map[Component]GridSpan),
+	}
+	l.owner = l
+	return l
+}
+
+// NewAutoGridLayout creates a new grid layout that fits as many columns as
+// possible, each at least minColumnWidth pixels wide.
+func NewAutoGridLayout(minColumnWidth int) *GridLayout {
+	l := NewGridLayout(1)
+	l.columns = 0
+	l.minColumnWidth = minColumnWidth
+	return l
+}
+
+// AddWithSpan adds a component that spans the given number of rows and columns.
+func (l *GridLayout) AddWithSpan(c Component, rowSpan, columnSpan int) {
+	l.mu.Lock()
+	l.components = append(l.components, c)
+	l.spans[c] = normalizeSpan(rowSpan, columnSpan)
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetSpan sets the number of rows and columns a component spans.
+func (l *GridLayout) SetSpan(c Component, rowSpan, columnSpan int) {
+	l.mu.Lock()
+	l.spans[c] = normalizeSpan(rowSpan, columnSpan)
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetColumns sets a fixed number of columns.
+func (l *GridLayout) SetColumns(columns int) {
+	if columns < 1 {
+		columns = 1
+	}
+	l.mu.Lock()
+	l.columns = columns
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetAutoColumns fits as many columns as possible, each at least
+// minColumnWidth pixels wide. Column widths are ignored.
+func (l *GridLayout) SetAutoColumns(minColumnWidth int) {
+	l.mu.Lock()
+	l.columns = 0
+	l.minColumnWidth = minColumnWidth
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetColumnWidths sets the widths of the columns from the left. Columns
+// without a width take an equal share of the remaining space.
+func (l *GridLayout) SetColumnWidths(widths ...ColumnWidth) {
+	l.mu.Lock()
+	l.columnWidths = append([]ColumnWidth(nil), widths...)
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetGaps sets the space between rows and between columns. Negative gaps
+// count as zero. Until it is called, the spacing of the layout is used for
+// both.
+func (l *GridLayout) SetGaps(rowGap, columnGap int) {
+	l.mu.Lock()
+	l.rowGap = nonNegative(rowGap)
+	l.columnGap = nonNegative(columnGap)
+	l.gapsSet = true
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Columns returns the fixed number of columns, or zero if the number of
+// columns depends on the available width.
+func (l *GridLayout) Columns() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.columns
+}
+
+// MinColumnWidth returns the minimum width of automatically fitted columns.
+func (l *GridLayout) MinColumnWidth() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.minColumnWidth
+}
+
+// ColumnWidths returns the width of every column for the given number of
+// columns. Columns without a width take a fraction of one.
+func (l *GridLayout) ColumnWidths(columns int) []ColumnWidth {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	widths := make([]ColumnWidth, columns)
+	for i := range widths {
+		if l.columns > 0 && i < len(l.columnWidths) {
+			widths[i] = l.columnWidths[i]
+		} else {
+			widths[i] = Fraction(1)
+		}
+	}
+	return widths
+}
+
+// Gaps returns the space between rows and between columns.
+func (l *GridLayout) Gaps() (rowGap, columnGap int) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if !l.gapsSet {
+		spacing := nonNegative(l.spacing)
+		return spacing, spacing
+	}
+	return l.rowGap, l.columnGap
+}
+
+// Span returns the number of rows and columns a component spans.
+func (l *GridLayout) Span(c Component) (rowSpan, columnSpan int) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	span := l.span(c)
+	return span.Rows, span.Columns
+}
+
+// Spans returns the spans of the components, in the order of Components.
+func (l *GridLayout) Spans() []GridSpan {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	spans := make([]GridSpan, len(l.components))
+	for i, c := range l.components {
+		spans[i] = l.span(c)
+	}
+	return spans
+}
+
+// span returns the span of a component. The caller must hold the lock.
+func (l *GridLayout) span(c Component) GridSpan {
+	if span, ok := l.spans[c]; ok {
+		return span
+	}
+	return GridSpan{Rows: 1, Columns: 1}
+}
+
+// normalizeSpan makes sure a cell spans at least one row and column.
+func normalizeSpan(rowSpan, columnSpan int) GridSpan {
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	if columnSpan < 1 {
+		columnSpan = 1
+	}
+	return GridSpan{Rows: rowSpan, Columns: columnSpan}
+}
+
+// AutoColumnCount returns how many columns of at least minColumnWidth fit in
+// the given width. There is always at least one column. Negative gaps count
+// as zero.
+func AutoColumnCount(width, minColumnWidth, columnGap int) int {
+	if minColumnWidth <= 0 {
+		return 1
+	}
+	columnGap = nonNegative(columnGap)
+	count := (width + columnGap) / (minColumnWidth + columnGap)
+	if count < 1 {
+		return 1
+	}
+	return count
+}
+
+// PlaceGrid places cells with the given spans on a grid with the given
+// number of columns, the way CSS grid auto-placement does. Each cell goes
+// into the first free area after the previous cell that is large enough.
+// Cells wider than the grid are narrowed to fit.
+func PlaceGrid(spans []GridSpan, columns int) []GridCell {
+	if columns < 1 {
+		columns = 1
+	}
+
+	occupied := make(map[[2]int]bool)
+	free := func(row, column int, span GridSpan) bool {
+		for r := row; r < row+span.Rows; r++ {
+			for c := column; c < column+span.Columns; c++ {
+				if occupied[[2]int{r, c}] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	cells := make([]GridCell, len(spans))
+	row, column := 0, 0
+	for i, span := range spans {
+		span = normalizeSpan(span.Rows, span.Columns)
+		if span.Columns > columns {
+			span.Columns = columns
+		}
+		for column+span.Columns > columns || !free(row, column, span) {
+			column++
+			if column+span.Columns > columns {
+				row++
+				column = 0
+			}
+		}
+
+		for r := row; r < row+span.Rows; r++ {
+			for c := column; c < column+span.Columns; c++ {
+				occupied[[2]int{r, c}] = true
+			}
+		}
+		cells[i] = GridCell{Row: row, Column: column, RowSpan: span.Rows, ColumnSpan: span.Columns}
+		column += span.Columns
+	}
+	return cells
+}
+
+// Render renders the layout to a string, one line of cells per row.
+func (l *GridLayout) Render() string {
+	columns := l.Columns()
+	if columns == 0 {
+		// Without a width to fit, show the components one per row
+		columns = 1
+	}
+	components := l.Components()
+	cells := PlaceGrid(l.Spans(), columns)
+
+	var rows [][]string
+	for i, cell := range cells {
+		for len(rows) <= cell.Row {
+			rows = append(rows, nil)
+		}
+		rows[cell.Row] = append(rows[cell.Row], components[i].Render())
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = strings.Join(row, " | ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MeasureLayout returns the range of sizes of the grid. Grids fitting their
+// columns to the width need room for one column.
+func (l *GridLayout) MeasureLayout(e *Engine) SizeHint {
+	columns := l.Columns()
+	if columns == 0 {
+		columns = 1
+	}
+	components, cells := l.place(columns)
+	hints := measureAll(e, components)
+	rowGap, columnGap := l.Gaps()
+	padding := l.Padding()
+
+	var hint SizeHint
+	for _, preferred := range []bool{false, true} {
+		widths, heights := l.tracks(hints, cells, columns, 0, preferred)
+		size := Size{Width: sumTracks(widths, columnGap), Height: sumTracks(heights, rowGap)}
+		if preferred {
+			hint.Preferred = size
+		} else {
+			hint.Min = size
+		}
+	}
+	return hint.padded(padding)
+}
+
+// ArrangeLayout places every component in its cell, stretching it across
+// the cell. Rows are as tall as their tallest component.
+func (l *GridLayout) ArrangeLayout(e *Engine, bounds Rect) []*Box {
+	bounds = bounds.inset(l.Padding())
+	rowGap, columnGap := l.Gaps()
+	columns := l.Columns()
+	if columns == 0 {
+		columns = AutoColumnCount(bounds.Width, l.MinColumnWidth(), columnGap)
+	}
+	components, cells := l.place(columns)
+	widths, heights := l.tracks(measureAll(e, components), cells, columns, bounds.Width, true)
+
+	boxes := make([]*Box, len(cells))
+	for i, cell := range cells {
+		x := sumTracks(widths[:cell.Column], columnGap)
+		y := sumTracks(heights[:cell.Row], rowGap)
+		if cell.Column > 0 {
+			x += columnGap
+		}
+		if cell.Row > 0 {
+			y += rowGap
+		}
+		boxes[i] = e.Arrange(components[i], Rect{
+			X:      bounds.X + x,
+			Y:      bounds.Y + y,
+			Width:  sumTracks(widths[cell.Column:cell.Column+cell.ColumnSpan], columnGap),
+			Height: sumTracks(heights[cell.Row:cell.Row+cell.RowSpan], rowGap),
+		})
+	}
+	return boxes
+}
+
+// place returns the components that are set and their cells on a grid with
+// the given number of columns.
+func (l *GridLayout) place(columns int) ([]Component, []GridCell) {
+	all, allSpans := l.Components(), l.Spans()
+	components := make([]Component, 0, len(all))
+	spans := make([]GridSpan, 0, len(all))
+	for i, c := range all {
+		if c != nil && i < len(allSpans) {
+			components = append(components, c)
+			spans = append(spans, allSpans[i])
+		}
+	}
+	return components, PlaceGrid(spans, columns)
+}
+
+// tracks returns the widths of the columns and the heights of the rows,
+// fitting either the minimum or the preferred sizes of the cells. Auto and
+// fraction columns are as wide as their widest cell, and fraction columns
+// share the rest of the width, if any, in proportion to their share.
+func (l *GridLayout) tracks(hints []SizeHint, cells []GridCell, columns, width int, preferred bool) ([]int, []int) {
+	rows := 0
+	for _, cell := range cells {
+		rows = maxInt(rows, cell.Row+cell.RowSpan)
+	}
+	widths := make([]int, columns)
+	heights := make([]int, rows)
+
+	auto := l.Columns() == 0
+	specs := l.ColumnWidths(columns)
+	fixed := make([]bool, columns)
+	for i, spec := range specs {
+		switch {
+		case spec.Kind == FixedColumn:
+			widths[i] = int(spec.Value)
+			fixed[i] = true
+		case auto:
+			widths[i] = l.MinColumnWidth()
+		}
+	}
+
+	// Cells in a single track size it first, cells spanning several tracks
+	// then grow the tracks they don't fit in
+	rowGap, columnGap := l.Gaps()
+	for _, single := range []bool{true, false} {
+		for i, cell := range cells {
+			size := hints[i].Min
+			if preferred {
+				size = hints[i].Preferred
+			}
+			if (cell.ColumnSpan == 1) == single {
+				growTracks(widths[cell.Column:cell.Column+cell.ColumnSpan], fixed[cell.Column:cell.Column+cell.ColumnSpan], size.Width, columnGap)
+			}
+			if (cell.RowSpan == 1) == single {
+				growTracks(heights[cell.Row:cell.Row+cell.RowSpan], nil, size.Height, rowGap)
+			}
+		}
+	}
+
+	// Fraction columns share what is left, but never shrink below their
+	// content. Columns that would are taken out of the share until the rest
+	// fit.
+	sharing := make([]bool, columns)
+	for i, spec := range specs {
+		sharing[i] = spec.Kind == FractionColumn && spec.Value > 0
+	}
+	for {
+		remaining := width - columnGap*(columns-1)
+		var shares float64
+		for i := range widths {
+			if sharing[i] {
+				shares += specs[i].Value
+			} else {
+				remaining -= widths[i]
+			}
+		}
+		if shares == 0 || remaining <= 0 {
+			break
+		}
+
+		unit := float64(remaining) / shares
+		settled := true
+		for i := range widths {
+			if sharing[i] && float64(widths[i]) > unit*specs[i].Value {
+				sharing[i] = false
+				settled = false
+			}
+		}
+		if settled {
+			portions := make([]int, columns)
+			distribute(portions, remaining, func(i int) float64 {
+				if !sharing[i] {
+					return 0
+				}
+				return specs[i].Value / shares
+			})
+			for i := range widths {
+				if sharing[i] {
+					widths[i] = portions[i]
+				}
+			}
+			break
+		}
+	}
+	return widths, heights
+}
+
+// measureAll measures every component.
+func measureAll(e *Engine, components []Component) []SizeHint {
+	hints := make([]SizeHint, len(components))
+	for i, c := range components {
+		hints[i] = e.Measure(c)
+	}
+	return hints
+}
+
+// growTracks widens the tracks that aren't fixed evenly until they add up to
+// the given size, including the gaps between them.
+func growTracks(tracks []int, fixed []bool, size, gap int) {
+	missing := size - sumTracks(tracks, gap)
+	growing := 0
+	for i := range tracks {
+		if fixed == nil || !fixed[i] {
+			growing++
+		}
+	}
+	if missing <= 0 || growing == 0 {
+		return
+	}
+	for i := range tracks {
+		if fixed == nil || !fixed[i] {
+			// The first tracks take the pixels left over by the division
+			extra := missing / growing
+			if missing%growing > 0 {
+				extra++
+			}
+			tracks[i] += extra
+			missing -= extra
+			growing--
+		}
+	}
+}
+
+// sumTracks returns the total size of a run of tracks with gaps between them.
+func sumTracks(tracks []int, gap int) int {
+	total := 0
+	for i, track := range tracks {
+		if i > 0 {
+			total += gap
+		}
+		total += track
+	}
+	return total
+}
@@ -3,6 +3,7 @@ package layout
 
 import (
 	"strings"
+	"sync"
 
 	"gonic/shared"
 )
@@ -13,6 +14,9 @@ type Component = shared.Component
 
 // BaseLayout provides common functionality for all layouts.
 type BaseLayout struct {
+	shared.Notifier
+	mu         sync.RWMutex
+	owner      Component
 	components []Component
 	padding    int
 	spacing    int
@@ -20,17 +24,55 @@ type BaseLayout struct {
 
 // Add adds components to the layout.
 func (l *BaseLayout) Add(components ...Component) {
+	l.mu.Lock()
 	l.components = append(l.components, components...)
+	l.mu.Unlock()
+	l.changed()
 }
 
 // SetPadding sets the padding for the layout.
 func (l *BaseLayout) SetPadding(padding int) {
+	l.mu.Lock()
 	l.padding = padding
+	l.mu.Unlock()
+	l.changed()
 }
 
 // SetSpacing sets the spacing between components in the layout.
 func (l *BaseLayout) SetSpacing(spacing int) {
+	l.mu.Lock()
 	l.spacing = spacing
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Components returns a copy of the components in the layout.
+func (l *BaseLayout) Components() []Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]Component(nil), l.components...)
+}
+
+// Padding returns the padding of the layout.
+func (l *BaseLayout) Padding() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.padding
+}
+
+// Spacing returns the spacing between components in the layout.
+func (l *BaseLayout) Spacing() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.spacing
+}
+
+// changed notifies listeners that the layout has changed.
+// Listeners receive the layout that embeds this BaseLayout.
+func (l *BaseLayout) changed() {
+	if l.owner != nil {
+		l.Notify(l.owner)
+	}
 }
 
 // StackLayout arranges components vertically, one on top of another.
@@ -40,17 +82,22 @@ type StackLayout struct {
 
 // NewStackLayout creates a new stack layout.
 func NewStackLayout() *StackLayout {
-	return &StackLayout{
+	l := &StackLayout{
 		BaseLayout: BaseLayout{
 			components: make([]Component, 0),
 			padding:    0,
 			spacing:    0,
 		},
 	}
+	l.owner = l
+	return l
 }
 
 // Render renders the layout to a string.
 func (l *StackLayout) Render() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
 	var builder strings.Builder
 
 	// Add padding at the top
@@ -78,6 +125,18 @@ func (l *StackLayout) Render() string {
 	return builder.String()
 }
 
+// MeasureLayout returns the range of sizes of the components stacked with
+// the layout's spacing and padding.
+func (l *StackLayout) MeasureLayout(e *Engine) SizeHint {
+	return measureLine(newFlexItems(e, l.Components()), verticalAxis, l.Spacing()).padded(l.Padding())
+}
+
+// ArrangeLayout stacks the components from the top, stretching them across
+// the layout.
+func (l *StackLayout) ArrangeLayout(e *Engine, bounds Rect) []*Box {
+	return arrangeLine(e, newFlexItems(e, l.Components()), verticalAxis, bounds.inset(l.Padding()), l.Spacing())
+}
+
 // Direction represents the direction of a flex layout.
 type Direction int
 
@@ -92,27 +151,222 @@ const (
 type FlexLayout struct {
 	BaseLayout
 	direction Direction
+	justify   Justify
+	alignment Alignment
+	wrap      bool
+	children  map[Component]FlexChild
+}
+
+// FlexChild sets how a component of a flex layout grows and shrinks along
+// the layout's direction.
+type FlexChild struct {
+	// Grow is the component's share of the space left on its line.
+	Grow float64
+	// Shrink is how much the component gives up, relative to its basis,
+	// when its line is too short.
+	Shrink float64
+	// Basis is the size in pixels the component starts from before it grows
+	// or shrinks, or BasisAuto to start from its preferred size.
+	Basis int
+}
+
+// DefaultFlexChild returns the settings of components that keep their
+// preferred size and only shrink when space is short.
+func DefaultFlexChild() FlexChild {
+	return FlexChild{Shrink: 1, Basis: BasisAuto}
 }
 
 // NewFlexLayout creates a new flex layout.
 func NewFlexLayout() *FlexLayout {
-	return &FlexLayout{
+	l := &FlexLayout{
 		BaseLayout: BaseLayout{
 			components: make([]Component, 0),
 			padding:    0,
 			spacing:    0,
 		},
 		direction: Vertical, // Default direction is vertical
+		children:  make(map[Component]FlexChild),
 	}
+	l.owner = l
+	return l
 }
 
 // SetDirection sets the direction of the flex layout.
 func (l *FlexLayout) SetDirection(direction Direction) {
+	l.mu.Lock()
 	l.direction = direction
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Direction returns the direction of the flex layout.
+func (l *FlexLayout) Direction() Direction {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.direction
+}
+
+// SetJustify sets how the components share the space left along the
+// layout's direction.
+func (l *FlexLayout) SetJustify(justify Justify) {
+	l.mu.Lock()
+	l.justify = justify
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Justify returns how the components share the space left along the
+// layout's direction.
+func (l *FlexLayout) Justify() Justify {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.justify
+}
+
+// SetAlignment sets where the components are placed across the layout's
+// direction. By default they are stretched across it.
+func (l *FlexLayout) SetAlignment(alignment Alignment) {
+	l.mu.Lock()
+	l.alignment = alignment
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Alignment returns where the components are placed across the layout's
+// direction.
+func (l *FlexLayout) Alignment() Alignment {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.alignment
+}
+
+// SetWrap sets whether components that don't fit on one line wrap onto the
+// next. The spacing of the layout separates the lines too.
+func (l *FlexLayout) SetWrap(wrap bool) {
+	l.mu.Lock()
+	l.wrap = wrap
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Wrap reports whether components wrap onto several lines.
+func (l *FlexLayout) Wrap() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.wrap
+}
+
+// SetFlex sets how a component grows and shrinks.
+func (l *FlexLayout) SetFlex(c Component, child FlexChild) {
+	l.mu.Lock()
+	l.children[c] = child
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetGrow sets a component's share of the space left on its line, keeping
+// its other settings.
+func (l *FlexLayout) SetGrow(c Component, grow float64) {
+	l.mu.Lock()
+	child := l.child(c)
+	child.Grow = grow
+	l.children[c] = child
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Flex returns how a component grows and shrinks.
+func (l *FlexLayout) Flex(c Component) FlexChild {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.child(c)
+}
+
+// child returns the settings of a component. The caller must hold the lock.
+func (l *FlexLayout) child(c Component) FlexChild {
+	if child, ok := l.children[c]; ok {
+		return child
+	}
+	return DefaultFlexChild()
+}
+
+// MeasureHints returns the range of sizes of components with the given size
+// hints, including the layout's padding. Renderers that measure components
+// themselves use it to follow the rules of the layout.
+func (l *FlexLayout) MeasureHints(components []Component, hints []SizeHint) SizeHint {
+	return l.box().measure(l.items(components, hints)).padded(l.Padding())
+}
+
+// Place returns the area of each component inside bounds, given their size
+// hints. Renderers that measure components themselves use it to follow the
+// rules of the layout.
+func (l *FlexLayout) Place(components []Component, hints []SizeHint, bounds Rect) []Rect {
+	return l.box().place(l.items(components, hints), bounds.inset(l.Padding()))
+}
+
+// MeasureLayout returns the range of sizes of the components placed one
+// after another with the layout's spacing and padding.
+func (l *FlexLayout) MeasureLayout(e *Engine) SizeHint {
+	components := nonNil(l.Components())
+	return l.MeasureHints(components, measureAll(e, components))
+}
+
+// ArrangeLayout places the components one after another in the layout's
+// direction, growing, shrinking, justifying, aligning and wrapping them as
+// set.
+func (l *FlexLayout) ArrangeLayout(e *Engine, bounds Rect) []*Box {
+	components := nonNil(l.Components())
+	items := l.items(components, measureAll(e, components))
+	return l.box().arrange(e, items, bounds.inset(l.Padding()))
+}
+
+// box returns the settings of the layout's lines.
+func (l *FlexLayout) box() flexBox {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return flexBox{
+		axis:    axis(l.direction == Horizontal),
+		spacing: l.spacing,
+		justify: l.justify,
+		wrap:    l.wrap,
+	}
+}
+
+// items returns the flex items of components with the given size hints.
+func (l *FlexLayout) items(components []Component, hints []SizeHint) []flexItem {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	items := make([]flexItem, len(components))
+	for i, c := range components {
+		child := l.child(c)
+		items[i] = flexItem{
+			component: c,
+			hint:      hints[i],
+			grow:      child.Grow,
+			shrink:    child.Shrink,
+			basis:     child.Basis,
+			align:     l.alignment,
+		}
+	}
+	return items
+}
+
+// nonNil returns the components that are set.
+func nonNil(components []Component) []Component {
+	set := make([]Component, 0, len(components))
+	for _, c := range components {
+		if c != nil {
+			set = append(set, c)
+		}
+	}
+	return set
 }
 
 // Render renders the layout to a string.
 func (l *FlexLayout) Render() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
 	var builder strings.Builder
 
 	// Add padding at the top/left
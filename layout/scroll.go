@@ -0,0 +1,175 @@
+package layout
+
+import (
+	"strings"
+)
+
+// ScrollDirection sets which ways a scroll container scrolls.
+type ScrollDirection int
+
+const (
+	// ScrollVertical scrolls content taller than the container up and down.
+	ScrollVertical ScrollDirection = iota
+	// ScrollHorizontal scrolls content wider than the container sideways.
+	ScrollHorizontal
+	// ScrollBoth scrolls content both ways.
+	ScrollBoth
+)
+
+// ScrollContainer shows a part of content too large for it, which the user
+// scrolls through. Its components are stacked vertically, like those of a
+// StackLayout.
+type ScrollContainer struct {
+	BaseLayout
+	direction ScrollDirection
+	width     int
+	height    int
+	target    Component
+	request   int
+}
+
+// NewScrollContainer creates a new vertically scrolling container holding
+// the given components.
+func NewScrollContainer(content ...Component) *ScrollContainer {
+	l := &ScrollContainer{
+		BaseLayout: BaseLayout{
+			components: append([]Component(nil), content...),
+		},
+	}
+	l.owner = l
+	return l
+}
+
+// SetDirection sets which ways the container scrolls.
+func (l *ScrollContainer) SetDirection(direction ScrollDirection) {
+	l.mu.Lock()
+	l.direction = direction
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetSize sets the size of the visible area in pixels. A size of zero fits
+// the area to the layout the container is in. Browsers only scroll
+// containers with a height, set here or by the layout they are in.
+func (l *ScrollContainer) SetSize(width, height int) {
+	l.mu.Lock()
+	l.width = width
+	l.height = height
+	l.mu.Unlock()
+	l.changed()
+}
+
+// ScrollTo scrolls the container until a component inside it is at the top
+// left of the visible area, or as close to it as the content allows.
+func (l *ScrollContainer) ScrollTo(c Component) {
+	l.mu.Lock()
+	l.target = c
+	l.request++
+	l.mu.Unlock()
+	l.changed()
+}
+
+// Direction returns which ways the container scrolls.
+func (l *ScrollContainer) Direction() ScrollDirection {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.direction
+}
+
+// Size returns the size of the visible area in pixels, or zero to fit it to
+// the layout the container is in.
+func (l *ScrollContainer) Size() (width, height int) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.width, l.height
+}
+
+// ScrollTarget returns the component last scrolled to with ScrollTo, or nil
+// if there is none, and a number that changes with every call to ScrollTo,
+// so that renderers know when to scroll again.
+func (l *ScrollContainer) ScrollTarget() (target Component, request int) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target, l.request
+}
+
+// scrolls reports whether the container scrolls along an axis.
+func (l *ScrollContainer) scrolls(a axis) bool {
+	switch l.Direction() {
+	case ScrollVertical:
+		return a == verticalAxis
+	case ScrollHorizontal:
+		return a == horizontalAxis
+	}
+	return true
+}
+
+// MeasureLayout returns the range of sizes of the container. Along the ways
+// it scrolls, it fits in any size and prefers the size of its content.
+func (l *ScrollContainer) MeasureLayout(e *Engine) SizeHint {
+	hint := measureLine(newFlexItems(e, l.Components()), verticalAxis, l.Spacing()).padded(l.Padding())
+	if l.scrolls(horizontalAxis) {
+		hint.Min.Width = 0
+	}
+	if l.scrolls(verticalAxis) {
+		hint.Min.Height = 0
+	}
+
+	width, height := l.Size()
+	if width > 0 {
+		hint.Min.Width, hint.Preferred.Width = width, width
+	}
+	if height > 0 {
+		hint.Min.Height, hint.Preferred.Height = height, height
+	}
+	return hint
+}
+
+// ArrangeLayout stacks the components in content as large as they prefer
+// along the ways the container scrolls, shifted to show the component last
+// scrolled to. Boxes outside the container are hidden by it, so hit tests
+// never find them.
+func (l *ScrollContainer) ArrangeLayout(e *Engine, bounds Rect) []*Box {
+	items := newFlexItems(e, l.Components())
+	preferred := measureLine(items, verticalAxis, l.Spacing()).padded(l.Padding()).Preferred
+
+	content := bounds
+	if l.scrolls(horizontalAxis) {
+		content.Width = maxInt(bounds.Width, preferred.Width)
+	}
+	if l.scrolls(verticalAxis) {
+		content.Height = maxInt(bounds.Height, preferred.Height)
+	}
+	boxes := arrangeLine(e, items, verticalAxis, content.inset(l.Padding()), l.Spacing())
+
+	target, _ := l.ScrollTarget()
+	if target == nil {
+		return boxes
+	}
+	for _, box := range boxes {
+		if found := box.Find(target); found != nil {
+			dx := minInt(found.Rect.X-bounds.X, content.Width-bounds.Width)
+			dy := minInt(found.Rect.Y-bounds.Y, content.Height-bounds.Height)
+			for _, box := range boxes {
+				box.translate(-nonNegative(dx), -nonNegative(dy))
+			}
+			break
+		}
+	}
+	return boxes
+}
+
+// Render renders the container to a string, listing its components like a
+// StackLayout.
+func (l *ScrollContainer) Render() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	lines := make([]string, 0, len(l.components))
+	for _, c := range l.components {
+		if c != nil {
+			lines = append(lines, c.Render())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,240 @@
+package layout
+
+import (
+	"math"
+)
+
+// DividerSize is the thickness in pixels of the divider between the panes
+// of a split pane.
+const DividerSize = 6
+
+// RatioChangeHandler is called with the new ratio when the user drags the
+// divider of a split pane.
+type RatioChangeHandler func(ratio float64)
+
+// SplitPane shows two components side by side or one above the other, with
+// a divider between them that the user drags to share the space.
+type SplitPane struct {
+	BaseLayout
+	direction   Direction
+	leading     Component
+	trailing    Component
+	ratio       float64
+	minLeading  int
+	minTrailing int
+	onChange    RatioChangeHandler
+}
+
+// NewSplitPane creates a new split pane with the divider in the middle.
+// Horizontal split panes show the leading component on the left, vertical
+// ones show it at the top.
+func NewSplitPane(direction Direction, leading, trailing Component) *SplitPane {
+	l := &SplitPane{
+		BaseLayout: BaseLayout{
+			components: make([]Component, 0),
+		},
+		direction: direction,
+		leading:   leading,
+		trailing:  trailing,
+		ratio:     0.5,
+	}
+	l.owner = l
+	return l
+}
+
+// Add fills the empty panes, leading first. Components beyond those are
+// ignored.
+func (l *SplitPane) Add(components ...Component) {
+	l.mu.Lock()
+	for _, c := range components {
+		if l.leading == nil {
+			l.leading = c
+		} else if l.trailing == nil {
+			l.trailing = c
+		}
+	}
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetLeading sets the component on the left or at the top.
+func (l *SplitPane) SetLeading(c Component) {
+	l.mu.Lock()
+	l.leading = c
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetTrailing sets the component on the right or at the bottom.
+func (l *SplitPane) SetTrailing(c Component) {
+	l.mu.Lock()
+	l.trailing = c
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetDirection sets whether the panes are side by side or one above the other.
+func (l *SplitPane) SetDirection(direction Direction) {
+	l.mu.Lock()
+	l.direction = direction
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetRatio sets the share of the space, from 0 to 1, given to the leading
+// pane. It does not trigger the OnRatioChange handler.
+func (l *SplitPane) SetRatio(ratio float64) {
+	l.mu.Lock()
+	l.ratio = clampRatio(ratio)
+	l.mu.Unlock()
+	l.changed()
+}
+
+// SetMinSizes sets the smallest widths, or heights for vertical split panes,
+// that dragging the divider leaves the panes.
+func (l *SplitPane) SetMinSizes(leading, trailing int) {
+	l.mu.Lock()
+	l.minLeading = leading
+	l.minTrailing = trailing
+	l.mu.Unlock()
+	l.changed()
+}
+
+// OnRatioChange sets the handler called when the user drags the divider,
+// e.g. to persist the ratio between runs.
+func (l *SplitPane) OnRatioChange(handler RatioChangeHandler) {
+	l.mu.Lock()
+	l.onChange = handler
+	l.mu.Unlock()
+}
+
+// Leading returns the component on the left or at the top, or nil.
+func (l *SplitPane) Leading() Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.leading
+}
+
+// Trailing returns the component on the right or at the bottom, or nil.
+func (l *SplitPane) Trailing() Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.trailing
+}
+
+// Direction returns whether the panes are side by side or one above the other.
+func (l *SplitPane) Direction() Direction {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.direction
+}
+
+// Ratio returns the share of the space given to the leading pane.
+func (l *SplitPane) Ratio() float64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.ratio
+}
+
+// MinSizes returns the smallest sizes dragging the divider leaves the panes.
+func (l *SplitPane) MinSizes() (leading, trailing int) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.minLeading, l.minTrailing
+}
+
+// Components returns the panes that are set, leading first.
+func (l *SplitPane) Components() []Component {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return nonNil([]Component{l.leading, l.trailing})
+}
+
+// MoveDivider simulates the user dragging the divider, which triggers the
+// OnRatioChange handler. The ratio is kept between 0 and 1.
+func (l *SplitPane) MoveDivider(ratio float64) {
+	l.mu.Lock()
+	ratio = clampRatio(ratio)
+	if ratio == l.ratio {
+		l.mu.Unlock()
+		return
+	}
+	l.ratio = ratio
+	onChange := l.onChange
+	l.mu.Unlock()
+
+	l.changed()
+	if onChange != nil {
+		onChange(ratio)
+	}
+}
+
+// clampRatio keeps a ratio between 0 and 1.
+func clampRatio(ratio float64) float64 {
+	if math.IsNaN(ratio) {
+		return 0.5
+	}
+	return math.Max(0, math.Min(1, ratio))
+}
+
+// Split returns the sizes of the leading and trailing panes along the
+// direction of the split pane, for a split pane of the given size. The
+// leading pane gets its share of the space left by the divider, within the
+// limits of the minimum sizes. Renderers without a split pane of their own
+// use it to place the panes.
+func (l *SplitPane) Split(size int) (leading, trailing int) {
+	minLeading, minTrailing := l.MinSizes()
+	available := nonNegative(size - DividerSize)
+	leading = int(math.Round(float64(available) * l.Ratio()))
+	leading = maxInt(minInt(leading, available-minTrailing), minLeading)
+	leading = minInt(leading, available)
+	return leading, available - leading
+}
+
+// axis returns the axis the panes are placed along.
+func (l *SplitPane) axis() axis {
+	return axis(l.Direction() == Horizontal)
+}
+
+// MeasureLayout returns the range of sizes of the panes with the divider
+// between them.
+func (l *SplitPane) MeasureLayout(e *Engine) SizeHint {
+	a := l.axis()
+	minLeading, minTrailing := l.MinSizes()
+	items := []flexItem{sizedItem(e.Measure(l.Leading())), sizedItem(e.Measure(l.Trailing()))}
+	for i, min := range []int{minLeading, minTrailing} {
+		hint := &items[i].hint
+		hint.Min = a.size(maxInt(a.main(hint.Min), min), a.cross(hint.Min))
+		hint.Preferred = a.size(maxInt(a.main(hint.Preferred), min), a.cross(hint.Preferred))
+	}
+	return measureLine(items, a, DividerSize).padded(l.Padding())
+}
+
+// ArrangeLayout places the panes on both sides of the divider, stretching
+// them across the split pane. The divider belongs to the split pane itself
+// in hit tests.
+func (l *SplitPane) ArrangeLayout(e *Engine, bounds Rect) []*Box {
+	a := l.axis()
+	bounds = bounds.inset(l.Padding())
+	crossSize := a.cross(bounds.Size())
+	leadingSize, trailingSize := l.Split(a.main(bounds.Size()))
+
+	var boxes []*Box
+	if leading := l.Leading(); leading != nil {
+		boxes = append(boxes, e.Arrange(leading, a.rect(bounds, 0, 0, leadingSize, crossSize)))
+	}
+	if trailing := l.Trailing(); trailing != nil {
+		boxes = append(boxes, e.Arrange(trailing, a.rect(bounds, leadingSize+DividerSize, 0, trailingSize, crossSize)))
+	}
+	return boxes
+}
+
+// Render renders the split pane to a string, with the panes side by side or
+// one above the other.
+func (l *SplitPane) Render() string {
+	leading, trailing := render(l.Leading()), render(l.Trailing())
+	if l.Direction() == Horizontal {
+		return sideBySide([]string{leading, trailing}, " || ")
+	}
+	return leading + "\n" + "------" + "\n" + trailing
+}
@@ -0,0 +1,352 @@
+package gonic
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+
+	"gonic/layout"
+	"gonic/shared"
+)
+
+// nativeGridLayout arranges objects on a grid, matching the geometry of
+// GridLayout and the CSS grid the web renderer uses for it
+type nativeGridLayout struct {
+	spans          []layout.GridSpan
+	columns        int
+	minColumnWidth float32
+	widths         []layout.ColumnWidth
+	rowGap         float32
+	columnGap      float32
+	padding        float32
+}
+
+// MinSize returns the size needed to fit every visible object. Grids fitting
+// their columns to the width need room for one column.
+func (l *nativeGridLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	objects, spans := l.visible(objects)
+	columns := l.columns
+	if columns == 0 {
+		columns = 1
+	}
+	cells := layout.PlaceGrid(spans, columns)
+	widths, heights := l.tracks(objects, cells, columns, 0)
+	return fyne.NewSize(
+		sumTracks(widths, l.columnGap)+2*l.padding,
+		sumTracks(heights, l.rowGap)+2*l.padding,
+	)
+}
+
+// Layout places every visible object in its cell, stretching it across the
+// cell. Rows are as tall as their tallest object.
+func (l *nativeGridLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	objects, spans := l.visible(objects)
+	inner := size.Width - 2*l.padding
+	columns := l.columns
+	if columns == 0 {
+		columns = layout.AutoColumnCount(int(inner), int(l.minColumnWidth), int(l.columnGap))
+	}
+	cells := layout.PlaceGrid(spans, columns)
+	widths, heights := l.tracks(objects, cells, columns, inner)
+
+	for i, cell := range cells {
+		x := l.padding + sumTracks(widths[:cell.Column], l.columnGap)
+		y := l.padding + sumTracks(heights[:cell.Row], l.rowGap)
+		if cell.Column > 0 {
+			x += l.columnGap
+		}
+		if cell.Row > 0 {
+			y += l.rowGap
+		}
+		objects[i].Move(fyne.NewPos(x, y))
+		objects[i].Resize(fyne.NewSize(
+			sumTracks(widths[cell.Column:cell.Column+cell.ColumnSpan], l.columnGap),
+			sumTracks(heights[cell.Row:cell.Row+cell.RowSpan], l.rowGap),
+		))
+	}
+}
+
+// visible returns the visible objects with their spans
+func (l *nativeGridLayout) visible(objects []fyne.CanvasObject) ([]fyne.CanvasObject, []layout.GridSpan) {
+	shown := make([]fyne.CanvasObject, 0, len(objects))
+	spans := make([]layout.GridSpan, 0, len(objects))
+	for i, object := range objects {
+		if !object.Visible() || i >= len(l.spans) {
+			continue
+		}
+		shown = append(shown, object)
+		spans = append(spans, l.spans[i])
+	}
+	return shown, spans
+}
+
+// tracks returns the widths of the columns and the heights of the rows. Auto
+// and fraction columns are as wide as their widest object, and fraction
+// columns share the rest of the width, if any, in proportion to their share.
+func (l *nativeGridLayout) tracks(objects []fyne.CanvasObject, cells []layout.GridCell, columns int, width float32) ([]float32, []float32) {
+	rows := 0
+	for _, cell := range cells {
+		if cell.Row+cell.RowSpan > rows {
+			rows = cell.Row + cell.RowSpan
+		}
+	}
+	widths := make([]float32, columns)
+	heights := make([]float32, rows)
+
+	specs := make([]layout.ColumnWidth, columns)
+	fixed := make([]bool, columns)
+	for i := range specs {
+		specs[i] = layout.Fraction(1)
+		if l.columns > 0 && i < len(l.widths) {
+			specs[i] = l.widths[i]
+		}
+		switch {
+		case specs[i].Kind == layout.FixedColumn:
+			widths[i] = float32(specs[i].Value)
+			fixed[i] = true
+		case l.columns == 0:
+			widths[i] = l.minColumnWidth
+		}
+	}
+
+	// Objects in a single track size it first, objects spanning several
+	// tracks then grow the tracks they don't fit in
+	for _, single := range []bool{true, false} {
+		for i, cell := range cells {
+			min := objects[i].MinSize()
+			if (cell.ColumnSpan == 1) == single {
+				growTracks(widths[cell.Column:cell.Column+cell.ColumnSpan], fixed[cell.Column:cell.Column+cell.ColumnSpan], min.Width, l.columnGap)
+			}
+			if (cell.RowSpan == 1) == single {
+				growTracks(heights[cell.Row:cell.Row+cell.RowSpan], nil, min.Height, l.rowGap)
+			}
+		}
+	}
+
+	// Fraction columns share what is left, but never shrink below their
+	// content. Columns that would are taken out of the share until the
+	// rest fit.
+	sharing := make([]bool, columns)
+	for i, spec := range specs {
+		sharing[i] = spec.Kind == layout.FractionColumn && spec.Value > 0
+	}
+	for {
+		remaining := width - l.columnGap*float32(columns-1)
+		var shares float64
+		for i := range widths {
+			if sharing[i] {
+				shares += specs[i].Value
+			} else {
+				remaining -= widths[i]
+			}
+		}
+		if shares == 0 || remaining <= 0 {
+			break
+		}
+
+		unit := remaining / float32(shares)
+		settled := true
+		for i := range widths {
+			if sharing[i] && widths[i] > unit*float32(specs[i].Value) {
+				sharing[i] = false
+				settled = false
+			}
+		}
+		if settled {
+			for i := range widths {
+				if sharing[i] {
+					widths[i] = unit * float32(specs[i].Value)
+				}
+			}
+			break
+		}
+	}
+	return widths, heights
+}
+
+// growTracks widens the tracks that aren't fixed evenly until they add up to
+// the given size, including the gaps between them
+func growTracks(tracks []float32, fixed []bool, size, gap float32) {
+	missing := size - sumTracks(tracks, gap)
+	growing := 0
+	for i := range tracks {
+		if fixed == nil || !fixed[i] {
+			growing++
+		}
+	}
+	if missing <= 0 || growing == 0 {
+		return
+	}
+	for i := range tracks {
+		if fixed == nil || !fixed[i] {
+			tracks[i] += missing / float32(growing)
+		}
+	}
+}
+
+// sumTracks returns the total size of a run of tracks with gaps between them
+func sumTracks(tracks []float32, gap float32) float32 {
+	var total float32
+	for i, track := range tracks {
+		if i > 0 {
+			total += gap
+		}
+		total += track
+	}
+	return total
+}
+
+// nativeBorderLayout places objects along the edges of a container, matching
+// the geometry of BorderLayout. Objects other than the edges are stacked in
+// the center, and a lone center object fills it.
+type nativeBorderLayout struct {
+	top, bottom, left, right fyne.CanvasObject
+	spacing                  float32
+	padding                  float32
+}
+
+// MinSize returns the size needed to fit the edges around the center
+func (l *nativeBorderLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	var middle []fyne.Size
+	for _, edge := range []fyne.CanvasObject{l.left, l.right} {
+		if shown(edge) {
+			middle = append(middle, edge.MinSize())
+		}
+	}
+	if center := l.center(objects); len(center) > 0 {
+		middle = append(middle, (&nativeBoxLayout{spacing: l.spacing}).MinSize(center))
+	}
+
+	var rows []fyne.Size
+	for _, edge := range []fyne.CanvasObject{l.top, l.bottom} {
+		if shown(edge) {
+			rows = append(rows, edge.MinSize())
+		}
+	}
+	if len(middle) > 0 {
+		rows = append(rows, stackSizes(middle, l.spacing, true))
+	}
+	size := stackSizes(rows, l.spacing, false)
+	return fyne.NewSize(size.Width+2*l.padding, size.Height+2*l.padding)
+}
+
+// Layout gives the edges their minimum size across the container and the
+// center the rest
+func (l *nativeBorderLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	x, y := l.padding, l.padding
+	right, bottom := size.Width-l.padding, size.Height-l.padding
+
+	if shown(l.top) {
+		height := l.top.MinSize().Height
+		l.top.Move(fyne.NewPos(x, y))
+		l.top.Resize(fyne.NewSize(right-x, height))
+		y += height + l.spacing
+	}
+	if shown(l.bottom) {
+		height := l.bottom.MinSize().Height
+		l.bottom.Move(fyne.NewPos(x, bottom-height))
+		l.bottom.Resize(fyne.NewSize(right-x, height))
+		bottom -= height + l.spacing
+	}
+	if shown(l.left) {
+		width := l.left.MinSize().Width
+		l.left.Move(fyne.NewPos(x, y))
+		l.left.Resize(fyne.NewSize(width, bottom-y))
+		x += width + l.spacing
+	}
+	if shown(l.right) {
+		width := l.right.MinSize().Width
+		l.right.Move(fyne.NewPos(right-width, y))
+		l.right.Resize(fyne.NewSize(width, bottom-y))
+		right -= width + l.spacing
+	}
+
+	center := l.center(objects)
+	if len(center) == 1 {
+		center[0].Move(fyne.NewPos(x, y))
+		center[0].Resize(fyne.NewSize(right-x, bottom-y))
+		return
+	}
+	for _, object := range center {
+		min := object.MinSize()
+		object.Move(fyne.NewPos(x, y))
+		object.Resize(fyne.NewSize(right-x, min.Height))
+		y += min.Height + l.spacing
+	}
+}
+
+// center returns the visible objects that aren't on an edge
+func (l *nativeBorderLayout) center(objects []fyne.CanvasObject) []fyne.CanvasObject {
+	center := make([]fyne.CanvasObject, 0, len(objects))
+	for _, object := range objects {
+		if object.Visible() && object != l.top && object != l.bottom && object != l.left && object != l.right {
+			center = append(center, object)
+		}
+	}
+	return center
+}
+
+// shown reports whether an object is set and visible
+func shown(object fyne.CanvasObject) bool {
+	return object != nil && object.Visible()
+}
+
+// stackSizes returns the size of areas placed one after another with spacing
+// between them, side by side or top to bottom
+func stackSizes(sizes []fyne.Size, spacing float32, horizontal bool) fyne.Size {
+	var total fyne.Size
+	for i, size := range sizes {
+		gap := spacing
+		if i == 0 {
+			gap = 0
+		}
+		if horizontal {
+			total = fyne.NewSize(total.Width+gap+size.Width, maxFloat(total.Height, size.Height))
+		} else {
+			total = fyne.NewSize(maxFloat(total.Width, size.Width), total.Height+gap+size.Height)
+		}
+	}
+	return total
+}
+
+// nativeFlexLayout places objects by the rules of a flex layout, measuring
+// each object by its minimum size
+type nativeFlexLayout struct {
+	flex     *layout.FlexLayout
+	children []shared.Component
+}
+
+// MinSize returns the size needed to fit every visible object
+func (l *nativeFlexLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	_, children, hints := l.visible(objects)
+	hint := l.flex.MeasureHints(children, hints)
+	return fyne.NewSize(float32(hint.Min.Width), float32(hint.Min.Height))
+}
+
+// Layout moves and resizes every visible object to its area
+func (l *nativeFlexLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	objects, children, hints := l.visible(objects)
+	bounds := layout.Rect{Width: int(size.Width), Height: int(size.Height)}
+	for i, rect := range l.flex.Place(children, hints, bounds) {
+		objects[i].Move(fyne.NewPos(float32(rect.X), float32(rect.Y)))
+		objects[i].Resize(fyne.NewSize(float32(rect.Width), float32(rect.Height)))
+	}
+}
+
+// visible returns the visible objects with their components and size hints
+func (l *nativeFlexLayout) visible(objects []fyne.CanvasObject) ([]fyne.CanvasObject, []shared.Component, []layout.SizeHint) {
+	shown := make([]fyne.CanvasObject, 0, len(objects))
+	children := make([]shared.Component, 0, len(objects))
+	hints := make([]layout.SizeHint, 0, len(objects))
+	for i, object := range objects {
+		if !object.Visible() || i >= len(l.children) {
+			continue
+		}
+		min := object.MinSize()
+		size := layout.Size{Width: int(math.Ceil(float64(min.Width))), Height: int(math.Ceil(float64(min.Height)))}
+		shown = append(shown, object)
+		children = append(children, l.children[i])
+		hints = append(hints, layout.SizeHint{Min: size, Preferred: size})
+	}
+	return shown, children, hints
+}
@@ -0,0 +1,200 @@
+package gonic
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"gonic/components"
+	"gonic/internal"
+	"gonic/shared"
+)
+
+// nativeMenuBar keeps the main menu of a Fyne window and its keyboard
+// shortcuts in sync with the menus of a window
+type nativeMenuBar struct {
+	window    fyne.Window
+	menus     []*components.Menu
+	shortcuts []fyne.Shortcut
+	unwatch   []func()
+	mu        sync.Mutex
+}
+
+// newNativeMenuBar shows the menus as the main menu of a Fyne window
+func newNativeMenuBar(window fyne.Window, menus []*components.Menu) *nativeMenuBar {
+	m := &nativeMenuBar{window: window, menus: menus}
+	m.update()
+	return m
+}
+
+// update rebuilds the main menu and shortcuts from the menus. It runs on
+// the UI thread, and again whenever a menu or one of its items changes.
+func (m *nativeMenuBar) update() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stop()
+	fyneMenus := make([]*fyne.Menu, 0, len(m.menus))
+	for _, menu := range m.menus {
+		fyneMenus = append(fyneMenus, m.buildMenu(menu))
+	}
+	m.window.SetMainMenu(fyne.NewMainMenu(fyneMenus...))
+}
+
+// buildMenu creates the Fyne menu for a menu, watching it and its items for
+// changes and registering the shortcuts of its items
+func (m *nativeMenuBar) buildMenu(menu *components.Menu) *fyne.Menu {
+	m.watch(menu)
+	items := menu.Items()
+	fyneItems := make([]*fyne.MenuItem, 0, len(items))
+	for _, item := range items {
+		m.watch(item)
+		fyneItem := nativeMenuItem(item)
+		if submenu := item.Submenu(); submenu != nil {
+			fyneItem.ChildMenu = m.buildMenu(submenu)
+		}
+		// Fyne only handles the shortcuts of native macOS menus, elsewhere the
+		// canvas has to
+		if shortcut, ok := fyneItem.Shortcut.(*desktop.CustomShortcut); ok && runtime.GOOS != "darwin" {
+			item := item
+			m.window.Canvas().AddShortcut(shortcut, func(fyne.Shortcut) {
+				go item.Click()
+			})
+			m.shortcuts = append(m.shortcuts, shortcut)
+		}
+		fyneItems = append(fyneItems, fyneItem)
+	}
+	return fyne.NewMenu(menu.Label(), fyneItems...)
+}
+
+// watch rebuilds the main menu when a menu or item changes
+func (m *nativeMenuBar) watch(c shared.Watchable) {
+	m.unwatch = append(m.unwatch, c.Watch(func(shared.Component) {
+		fyne.Do(m.update)
+	}))
+}
+
+// close stops watching the menus
+func (m *nativeMenuBar) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stop()
+}
+
+// stop removes the shortcuts and stops watching the menus. The caller must
+// hold the lock.
+func (m *nativeMenuBar) stop() {
+	for _, cancel := range m.unwatch {
+		cancel()
+	}
+	for _, shortcut := range m.shortcuts {
+		m.window.Canvas().RemoveShortcut(shortcut)
+	}
+	m.unwatch = nil
+	m.shortcuts = nil
+}
+
+// nativeMenu creates a Fyne menu showing the current state of a menu
+func nativeMenu(menu *components.Menu) *fyne.Menu {
+	items := menu.Items()
+	fyneItems := make([]*fyne.MenuItem, 0, len(items))
+	for _, item := range items {
+		fyneItem := nativeMenuItem(item)
+		if submenu := item.Submenu(); submenu != nil {
+			fyneItem.ChildMenu = nativeMenu(submenu)
+		}
+		fyneItems = append(fyneItems, fyneItem)
+	}
+	return fyne.NewMenu(menu.Label(), fyneItems...)
+}
+
+// nativeMenuItem creates a Fyne menu item showing the current state of an item,
+// without its submenu
+func nativeMenuItem(item *components.MenuItem) *fyne.MenuItem {
+	if item.IsSeparator() {
+		return fyne.NewMenuItemSeparator()
+	}
+
+	// Handlers run on their own goroutine so they may block, e.g. on a dialog
+	fyneItem := fyne.NewMenuItem(item.Label(), func() {
+		go item.Click()
+	})
+	fyneItem.Checked = item.Checked()
+	fyneItem.Disabled = item.Disabled()
+	if shortcut := item.Shortcut(); !shortcut.IsZero() {
+		fyneItem.Shortcut = &desktop.CustomShortcut{
+			KeyName:  nativeKeyName(shortcut.Key),
+			Modifier: nativeModifiers(shortcut.Modifiers),
+		}
+	}
+	return fyneItem
+}
+
+// nativeKeyName maps a key code onto the name of the Fyne key
+func nativeKeyName(key internal.KeyCode) fyne.KeyName {
+	switch key {
+	case internal.KeyEscape:
+		return fyne.KeyEscape
+	case internal.KeyEnter:
+		return fyne.KeyReturn
+	case internal.KeySpace:
+		return fyne.KeySpace
+	case internal.KeyBackspace:
+		return fyne.KeyBackspace
+	case internal.KeyTab:
+		return fyne.KeyTab
+	}
+	return fyne.KeyName(strings.ToUpper(string(rune(key))))
+}
+
+// nativeModifiers maps key modifiers onto Fyne key modifiers
+func nativeModifiers(modifiers internal.KeyModifiers) fyne.KeyModifier {
+	var fyneModifiers fyne.KeyModifier
+	if modifiers&internal.ModShift != 0 {
+		fyneModifiers |= fyne.KeyModifierShift
+	}
+	if modifiers&internal.ModCtrl != 0 {
+		fyneModifiers |= fyne.KeyModifierControl
+	}
+	if modifiers&internal.ModAlt != 0 {
+		fyneModifiers |= fyne.KeyModifierAlt
+	}
+	if modifiers&internal.ModSuper != 0 {
+		fyneModifiers |= fyne.KeyModifierSuper
+	}
+	return fyneModifiers
+}
+
+// nativeContextMenu wraps the object of a component to show its context menu
+// when it is right-clicked. Widgets handling right-clicks themselves, such as
+// entries, keep their own menu.
+type nativeContextMenu struct {
+	widget.BaseWidget
+	content fyne.CanvasObject
+	menu    *components.Menu
+}
+
+// newNativeContextMenu wraps an object with a context menu
+func newNativeContextMenu(content fyne.CanvasObject, menu *components.Menu) *nativeContextMenu {
+	c := &nativeContextMenu{content: content, menu: menu}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// CreateRenderer returns the renderer of the wrapped object
+func (c *nativeContextMenu) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.content)
+}
+
+// TappedSecondary shows the context menu where the object was right-clicked
+func (c *nativeContextMenu) TappedSecondary(e *fyne.PointEvent) {
+	canvas := fyne.CurrentApp().Driver().CanvasForObject(c)
+	if canvas == nil {
+		return
+	}
+	widget.ShowPopUpMenuAtPosition(nativeMenu(c.menu), canvas, e.AbsolutePosition)
+}
@@ -0,0 +1,980 @@
+package gonic
+
+import (
+	"context"
+	"errors"
+	"image/color"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"gonic/components"
+	"gonic/internal"
+	"gonic/layout"
+	"gonic/shared"
+)
+
+// NativeRenderer displays windows as native windows by mapping each
+// component tree onto Fyne widgets
+type NativeRenderer struct {
+	backend      *internal.FyneRenderer
+	objects      map[shared.Component]fyne.CanvasObject
+	contextMenus map[shared.Component]*components.Menu
+	queues       map[shared.Component]*handlerQueue
+	unwatch      map[shared.Component]func()
+	menuBars     []func()
+	windows      []fyne.Window
+	mu           sync.Mutex
+
+	// The children every container shows, and how many containers or
+	// windows show every component. Components no one shows are released.
+	children map[shared.Component][]shared.Component
+	parents  map[shared.Component]int
+}
+
+// NewNativeRenderer creates a native renderer using an initialized Fyne backend
+func NewNativeRenderer(backend *internal.FyneRenderer) *NativeRenderer {
+	return &NativeRenderer{
+		backend:      backend,
+		objects:      make(map[shared.Component]fyne.CanvasObject),
+		contextMenus: make(map[shared.Component]*components.Menu),
+		queues:       make(map[shared.Component]*handlerQueue),
+		unwatch:      make(map[shared.Component]func()),
+		children:     make(map[shared.Component][]shared.Component),
+		parents:      make(map[shared.Component]int),
+	}
+}
+
+// Run displays all windows and blocks until they are closed
+func (r *NativeRenderer) Run(windows []*Window) {
+	_ = r.RunContext(context.Background(), windows)
+}
+
+// RunContext displays all windows and blocks until they are closed or the
+// context is done
+func (r *NativeRenderer) RunContext(ctx context.Context, windows []*Window) error {
+	fyneApp := r.backend.App()
+	for _, window := range windows {
+		r.ShowWindow(window)
+	}
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			fyne.Do(fyneApp.Quit)
+		case <-stopped:
+		}
+	}()
+
+	fyneApp.Run()
+	r.close()
+	return nil
+}
+
+// ShowWindow creates a native window for the given window and shows it
+func (r *NativeRenderer) ShowWindow(window *Window) fyne.Window {
+	fyneWindow := r.backend.App().NewWindow(window.title)
+	fyneWindow.Resize(fyne.NewSize(float32(window.width), float32(window.height)))
+
+	// Context menus must be known before the objects they wrap are built
+	r.mu.Lock()
+	for c, menu := range window.contextMenus {
+		r.contextMenus[c] = menu
+	}
+	r.mu.Unlock()
+	if len(window.menus) > 0 {
+		bar := newNativeMenuBar(fyneWindow, window.menus)
+		r.mu.Lock()
+		r.menuBars = append(r.menuBars, bar.close)
+		r.mu.Unlock()
+	}
+	if window.content != nil {
+		// The window shows its content for as long as the renderer runs
+		r.mu.Lock()
+		r.parents[window.content]++
+		r.mu.Unlock()
+		fyneWindow.SetContent(r.Object(window.content))
+	} else {
+		fyneWindow.SetContent(container.NewStack())
+	}
+	fyneWindow.Show()
+
+	r.mu.Lock()
+	r.windows = append(r.windows, fyneWindow)
+	r.mu.Unlock()
+	return fyneWindow
+}
+
+// Object returns the Fyne object displaying a component, building it on first
+// use. The object is kept in sync with the component until no window or
+// container shows the component any more.
+func (r *NativeRenderer) Object(c shared.Component) fyne.CanvasObject {
+	r.mu.Lock()
+	if object, ok := r.objects[c]; ok {
+		r.mu.Unlock()
+		return object
+	}
+	r.mu.Unlock()
+
+	object := r.build(c)
+	shown := object
+
+	r.mu.Lock()
+	if menu, ok := r.contextMenus[c]; ok {
+		shown = newNativeContextMenu(object, menu)
+	}
+	r.objects[c] = shown
+	queue := r.queues[c]
+	r.mu.Unlock()
+
+	// Objects released since the component changed are left alone
+	refresh := func() {
+		if current, ok := r.built(c); ok && current == shown {
+			r.update(c, object)
+		}
+	}
+
+	// Widgets catch up with their component once the user's changes are handled
+	if queue != nil {
+		queue.onIdle(func() {
+			fyne.Do(refresh)
+		})
+	}
+
+	// Components change from any goroutine, widgets only on the UI thread
+	if w, ok := c.(shared.Watchable); ok {
+		cancel := w.Watch(func(shared.Component) {
+			fyne.Do(refresh)
+		})
+		r.mu.Lock()
+		r.unwatch[c] = cancel
+		r.mu.Unlock()
+	}
+	return shown
+}
+
+// built returns the Fyne object of a component if it has been built
+func (r *NativeRenderer) built(c shared.Component) (fyne.CanvasObject, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	object, ok := r.objects[c]
+	return object, ok
+}
+
+// show records the children a container shows once it has been rebuilt.
+// Children it no longer shows are released unless another container shows
+// them, e.g. because they moved there.
+func (r *NativeRenderer) show(parent shared.Component, children []shared.Component) {
+	r.mu.Lock()
+	previous := r.children[parent]
+	if len(children) > 0 {
+		r.children[parent] = children
+	} else {
+		delete(r.children, parent)
+	}
+	for _, c := range children {
+		r.parents[c]++
+	}
+	var released []shared.Component
+	for _, c := range previous {
+		if r.parents[c]--; r.parents[c] <= 0 {
+			delete(r.parents, c)
+			released = append(released, c)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, c := range released {
+		r.release(c)
+	}
+}
+
+// release forgets the object of a component no one shows any more and
+// stops keeping it in sync, then releases the children it showed
+func (r *NativeRenderer) release(c shared.Component) {
+	r.mu.Lock()
+	cancel := r.unwatch[c]
+	delete(r.unwatch, c)
+	delete(r.objects, c)
+	delete(r.queues, c)
+	r.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	r.show(c, nil)
+}
+
+// close stops watching components once the windows are gone
+func (r *NativeRenderer) close() {
+	r.mu.Lock()
+	unwatch := make([]func(), 0, len(r.unwatch)+len(r.menuBars))
+	for _, cancel := range r.unwatch {
+		unwatch = append(unwatch, cancel)
+	}
+	unwatch = append(unwatch, r.menuBars...)
+	r.unwatch = make(map[shared.Component]func())
+	r.menuBars = nil
+	r.windows = nil
+	r.mu.Unlock()
+
+	for _, cancel := range unwatch {
+		cancel()
+	}
+}
+
+// handlers returns the queue running the handlers of a component's user
+// events, creating it on first use
+func (r *NativeRenderer) handlers(c shared.Component) *handlerQueue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queue, ok := r.queues[c]
+	if !ok {
+		queue = &handlerQueue{}
+		r.queues[c] = queue
+	}
+	return queue
+}
+
+// handling reports whether handlers of a component's user events are
+// waiting or running
+func (r *NativeRenderer) handling(c shared.Component) bool {
+	r.mu.Lock()
+	queue := r.queues[c]
+	r.mu.Unlock()
+	return queue != nil && queue.busy()
+}
+
+// handlerQueue runs the handlers of a widget's user events one after another
+// on a goroutine of its own. Fyne calls widget callbacks on the UI thread,
+// where a handler blocking on a dialog would hang the UI, yet events such as
+// typing must reach the component in order.
+type handlerQueue struct {
+	mu      sync.Mutex
+	pending []func()
+	running bool
+	idle    func()
+}
+
+// run queues a handler behind the ones waiting
+func (q *handlerQueue) run(handler func()) {
+	q.mu.Lock()
+	q.pending = append(q.pending, handler)
+	q.start()
+}
+
+// latest queues a handler in place of the ones waiting, for events such as
+// slider moves where only the last value matters
+func (q *handlerQueue) latest(handler func()) {
+	q.mu.Lock()
+	q.pending = append(q.pending[:0], handler)
+	q.start()
+}
+
+// start drains the queue unless it is already being drained, and releases
+// the lock
+func (q *handlerQueue) start() {
+	if q.running {
+		q.mu.Unlock()
+		return
+	}
+	q.running = true
+	q.mu.Unlock()
+	go q.drain()
+}
+
+// drain runs the waiting handlers, then calls the idle function
+func (q *handlerQueue) drain() {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			idle := q.idle
+			q.mu.Unlock()
+			if idle != nil {
+				idle()
+			}
+			return
+		}
+		handler := q.pending[0]
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+		handler()
+	}
+}
+
+// busy reports whether handlers are waiting or running
+func (q *handlerQueue) busy() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.running
+}
+
+// onIdle sets the function called whenever the queue has been drained
+func (q *handlerQueue) onIdle(idle func()) {
+	q.mu.Lock()
+	q.idle = idle
+	q.mu.Unlock()
+}
+
+// build creates the Fyne object for a component
+func (r *NativeRenderer) build(c shared.Component) fyne.CanvasObject {
+	var object fyne.CanvasObject
+	switch c := c.(type) {
+	case *components.Label:
+		object = widget.NewLabel("")
+	case *components.Button:
+		// Handlers run on their own goroutine so they may block, e.g. on a dialog
+		object = widget.NewButton("", func() {
+			go c.Click()
+		})
+	case *components.Spacer:
+		object = canvas.NewRectangle(color.Transparent)
+	case *components.TextInput:
+		object = r.buildTextInput(c)
+	case *components.Checkbox:
+		object = widget.NewCheck("", func(checked bool) {
+			r.handlers(c).run(func() {
+				c.Change(checked)
+			})
+		})
+	case *components.Switch:
+		// Fyne has no switch widget, a check box holds the same state
+		object = widget.NewCheck("", func(on bool) {
+			r.handlers(c).run(func() {
+				c.Change(on)
+			})
+		})
+	case *components.RadioGroup:
+		object = r.buildRadioGroup(c)
+	case *components.Select:
+		object = r.buildSelect(c)
+	case *components.ProgressBar:
+		// Both bars are kept so the progress bar can switch between them
+		object = container.NewStack(widget.NewProgressBar(), widget.NewProgressBarInfinite())
+	case *components.Table:
+		object = newNativeTable(c)
+	case *components.List:
+		object = newNativeList(c)
+	case *components.Tree:
+		object = newNativeTree(c)
+	case *components.Image:
+		object = newNativeImage(c)
+	case *components.Tabs:
+		object = r.buildTabs(c)
+	case *components.Navigator:
+		object = newNativeNavigator(c)
+	case *components.Slider:
+		min, max := c.Range()
+		slider := widget.NewSlider(min, max)
+		// Only the last of the values the slider moves through is handled
+		// when the handler can't keep up
+		slider.OnChanged = func(value float64) {
+			r.handlers(c).latest(func() {
+				c.Change(value)
+			})
+		}
+		object = slider
+	case *components.ComboBox:
+		entry := widget.NewSelectEntry(nil)
+		entry.OnChanged = func(text string) {
+			r.handlers(c).run(func() {
+				c.Change(text)
+			})
+		}
+		object = entry
+	case *layout.StackLayout:
+		object = container.New(&nativeBoxLayout{})
+	case *layout.FlexLayout:
+		object = container.New(&nativeFlexLayout{})
+	case *layout.GridLayout:
+		object = container.New(&nativeGridLayout{})
+	case *layout.BorderLayout:
+		object = container.New(&nativeBorderLayout{})
+	case *layout.ScrollContainer:
+		object = newNativeScroll(c)
+	case *layout.SplitPane:
+		object = newNativeSplit(c)
+	default:
+		// Unknown components fall back to their string representation
+		object = widget.NewLabel("")
+	}
+	r.update(c, object)
+	return object
+}
+
+// update copies the state of a component onto its Fyne object. Widgets
+// whose user events are still being handled are left as the user set them
+// until the handlers are done, then updated.
+func (r *NativeRenderer) update(c shared.Component, object fyne.CanvasObject) {
+	if r.handling(c) {
+		return
+	}
+	switch c := c.(type) {
+	case *components.Label:
+		label := object.(*widget.Label)
+		label.Text = c.Text()
+		label.TextStyle = fyne.TextStyle{Bold: c.Bold(), Italic: c.Italic()}
+		label.SizeName = textSizeName(c.FontSize())
+		label.Refresh()
+	case *components.Button:
+		button := object.(*widget.Button)
+		button.SetText(c.Text())
+		if c.Disabled() {
+			button.Disable()
+		} else {
+			button.Enable()
+		}
+	case *components.Spacer:
+		rect := object.(*canvas.Rectangle)
+		size := float32(c.Size())
+		rect.SetMinSize(fyne.NewSize(size, size))
+	case *components.TextInput:
+		entry := object.(*widget.Entry)
+		entry.SetPlaceHolder(c.Placeholder())
+		if entry.Text != c.Value() {
+			entry.SetText(c.Value())
+		}
+		if c.ReadOnly() {
+			entry.Disable()
+		} else {
+			entry.Enable()
+		}
+	case *components.Checkbox:
+		updateCheck(object.(*widget.Check), c.Label(), c.Checked(), c.Disabled())
+	case *components.Switch:
+		updateCheck(object.(*widget.Check), c.Label(), c.On(), c.Disabled())
+	case *components.RadioGroup:
+		radio := object.(*widget.RadioGroup)
+		radio.Options = c.Options()
+		// Setting the field directly doesn't call back into the group
+		radio.Selected = c.SelectedOption()
+		if c.Disabled() {
+			radio.Disable()
+		} else {
+			radio.Enable()
+		}
+		radio.Refresh()
+	case *components.Select:
+		sel := object.(*widget.Select)
+		sel.Options = optionLabels(c.Options())
+		sel.PlaceHolder = c.Placeholder()
+		// Setting the field directly doesn't call back into the select
+		selected, _ := c.Selected()
+		sel.Selected = selected.Label
+		if c.Disabled() {
+			sel.Disable()
+		} else {
+			sel.Enable()
+		}
+		sel.Refresh()
+	case *components.ComboBox:
+		entry := object.(*widget.SelectEntry)
+		entry.SetOptions(optionLabels(c.Options()))
+		entry.SetPlaceHolder(c.Placeholder())
+		if entry.Text != c.Text() {
+			entry.SetText(c.Text())
+		}
+		if c.Disabled() {
+			entry.Disable()
+		} else {
+			entry.Enable()
+		}
+	case *components.ProgressBar:
+		stack := object.(*fyne.Container)
+		bar := stack.Objects[0].(*widget.ProgressBar)
+		infinite := stack.Objects[1].(*widget.ProgressBarInfinite)
+		if c.Indeterminate() {
+			bar.Hide()
+			infinite.Show()
+			infinite.Start()
+		} else {
+			infinite.Stop()
+			infinite.Hide()
+			bar.Show()
+			bar.SetValue(c.Value())
+		}
+	case *components.Slider:
+		slider := object.(*widget.Slider)
+		slider.Min, slider.Max = c.Range()
+		slider.Step = c.Step()
+		if slider.Value != c.Value() {
+			slider.SetValue(c.Value())
+		}
+		if c.Disabled() {
+			slider.Disable()
+		} else {
+			slider.Enable()
+		}
+		slider.Refresh()
+	case *components.Table:
+		object.(*nativeTable).update()
+	case *components.List:
+		object.(*nativeList).update()
+	case *components.Tree:
+		object.(*nativeTree).update()
+	case *components.Image:
+		object.(*nativeImage).update()
+	case *components.Tabs:
+		r.updateTabs(object.(*container.AppTabs), c)
+	case *components.Navigator:
+		object.(*nativeNavigator).update(r)
+	case *layout.StackLayout:
+		r.updateBox(object.(*fyne.Container), c, false, &c.BaseLayout)
+	case *layout.FlexLayout:
+		r.updateFlex(object.(*fyne.Container), c)
+	case *layout.GridLayout:
+		r.updateGrid(object.(*fyne.Container), c)
+	case *layout.BorderLayout:
+		r.updateBorder(object.(*fyne.Container), c)
+	case *layout.ScrollContainer:
+		object.(*nativeScroll).update(r)
+	case *layout.SplitPane:
+		object.(*nativeSplit).update(r)
+	default:
+		if label, ok := object.(*widget.Label); ok {
+			label.SetText(c.Render())
+		}
+	}
+}
+
+// buildTextInput creates the entry for a text input and forwards what the user types
+func (r *NativeRenderer) buildTextInput(t *components.TextInput) *widget.Entry {
+	var entry *widget.Entry
+	switch t.Kind() {
+	case components.PasswordInput:
+		entry = widget.NewPasswordEntry()
+	case components.MultiLineInput:
+		entry = widget.NewMultiLineEntry()
+	default:
+		entry = widget.NewEntry()
+	}
+	// Handlers run off the UI thread so they may block, e.g. on a dialog.
+	// Changes and submits share one queue to reach the input in typing order;
+	// the input resets the entry when it rejects or shortens the text.
+	queue := r.handlers(t)
+	entry.OnChanged = func(text string) {
+		queue.run(func() {
+			t.Change(text)
+		})
+	}
+	entry.OnSubmitted = func(string) {
+		queue.run(t.Submit)
+	}
+	return entry
+}
+
+// buildRadioGroup creates the radio widget for a radio group
+func (r *NativeRenderer) buildRadioGroup(g *components.RadioGroup) *widget.RadioGroup {
+	radio := widget.NewRadioGroup(nil, nil)
+	// The group always keeps a selection once one is made
+	radio.Required = true
+	radio.OnChanged = func(option string) {
+		for i, o := range radio.Options {
+			if o == option {
+				r.handlers(g).run(func() {
+					g.Select(i)
+				})
+				return
+			}
+		}
+	}
+	return radio
+}
+
+// buildSelect creates the dropdown widget for a select
+func (r *NativeRenderer) buildSelect(s *components.Select) *widget.Select {
+	sel := widget.NewSelect(nil, nil)
+	sel.OnChanged = func(string) {
+		options := s.Options()
+		if i := sel.SelectedIndex(); i >= 0 && i < len(options) {
+			value := options[i].Value
+			r.handlers(s).run(func() {
+				s.Select(value)
+			})
+		}
+	}
+	return sel
+}
+
+// buildTabs creates the tab container for a tabs component
+func (r *NativeRenderer) buildTabs(t *components.Tabs) *container.AppTabs {
+	tabs := container.NewAppTabs()
+	tabs.OnSelected = r.tabSelected(t, tabs)
+	return tabs
+}
+
+// tabSelected returns the handler that forwards tabs selected by the user
+func (r *NativeRenderer) tabSelected(t *components.Tabs, tabs *container.AppTabs) func(*container.TabItem) {
+	return func(*container.TabItem) {
+		// Handlers run on their own goroutine so they may block, e.g. on a dialog
+		index := tabs.SelectedIndex()
+		go t.SelectTab(index)
+	}
+}
+
+// updateTabs rebuilds the tab items of a tab container from a tabs component
+func (r *NativeRenderer) updateTabs(tabs *container.AppTabs, t *components.Tabs) {
+	items := make([]*container.TabItem, 0, len(t.Tabs()))
+	var pages []shared.Component
+	for i, tab := range t.Tabs() {
+		var content fyne.CanvasObject = container.NewStack()
+		if tab.Content != nil {
+			content = r.Object(tab.Content)
+			pages = append(pages, tab.Content)
+		}
+		// Items showing the same page are kept so Fyne doesn't rebuild them
+		if i < len(tabs.Items) && tabs.Items[i].Content == content {
+			tabs.Items[i].Text = tab.Title
+			items = append(items, tabs.Items[i])
+			continue
+		}
+		items = append(items, container.NewTabItem(tab.Title, content))
+	}
+
+	// Fyne selects a tab of its own when the items change, which must not
+	// be reported as the user's choice
+	tabs.OnSelected = nil
+	tabs.SetItems(items)
+	if current := t.Current(); current >= 0 {
+		tabs.SelectIndex(current)
+	}
+	tabs.OnSelected = r.tabSelected(t, tabs)
+	tabs.Refresh()
+	r.show(t, pages)
+}
+
+// optionLabels returns the labels shown for a list of options
+func optionLabels(options []components.Option) []string {
+	labels := make([]string, len(options))
+	for i, option := range options {
+		labels[i] = option.Label
+	}
+	return labels
+}
+
+// updateCheck copies the state of a checkbox or switch onto a check widget
+func updateCheck(check *widget.Check, label string, checked, disabled bool) {
+	check.Text = label
+	// SetChecked calls back into the component only when the state differs
+	check.SetChecked(checked)
+	if disabled {
+		check.Disable()
+	} else {
+		check.Enable()
+	}
+	check.Refresh()
+}
+
+// updateBox rebuilds the box container of a layout from the layout's
+// children and settings
+func (r *NativeRenderer) updateBox(box *fyne.Container, owner shared.Component, horizontal bool, l *layout.BaseLayout) {
+	box.Layout = &nativeBoxLayout{
+		horizontal: horizontal,
+		spacing:    float32(l.Spacing()),
+		padding:    float32(l.Padding()),
+	}
+
+	children := l.Components()
+	shown := make([]shared.Component, 0, len(children))
+	objects := make([]fyne.CanvasObject, 0, len(children))
+	for _, child := range children {
+		if child != nil {
+			objects = append(objects, r.Object(child))
+			shown = append(shown, child)
+		}
+	}
+	box.Objects = objects
+	box.Refresh()
+	r.show(owner, shown)
+}
+
+// updateFlex rebuilds a flex container from a flex layout's children
+func (r *NativeRenderer) updateFlex(flex *fyne.Container, l *layout.FlexLayout) {
+	nativeLayout := &nativeFlexLayout{flex: l}
+	children := l.Components()
+	objects := make([]fyne.CanvasObject, 0, len(children))
+	for _, child := range children {
+		if child != nil {
+			objects = append(objects, r.Object(child))
+			nativeLayout.children = append(nativeLayout.children, child)
+		}
+	}
+	flex.Layout = nativeLayout
+	flex.Objects = objects
+	flex.Refresh()
+	r.show(l, nativeLayout.children)
+}
+
+// updateGrid rebuilds a grid container from a grid layout's children and settings
+func (r *NativeRenderer) updateGrid(grid *fyne.Container, l *layout.GridLayout) {
+	columns := l.Columns()
+	rowGap, columnGap := l.Gaps()
+	children, spans := l.Components(), l.Spans()
+	nativeLayout := &nativeGridLayout{
+		columns:        columns,
+		minColumnWidth: float32(l.MinColumnWidth()),
+		widths:         l.ColumnWidths(columns),
+		rowGap:         float32(rowGap),
+		columnGap:      float32(columnGap),
+		padding:        float32(l.Padding()),
+	}
+
+	shown := make([]shared.Component, 0, len(children))
+	objects := make([]fyne.CanvasObject, 0, len(children))
+	for i, child := range children {
+		if child != nil && i < len(spans) {
+			objects = append(objects, r.Object(child))
+			shown = append(shown, child)
+			nativeLayout.spans = append(nativeLayout.spans, spans[i])
+		}
+	}
+	grid.Layout = nativeLayout
+	grid.Objects = objects
+	grid.Refresh()
+	r.show(l, shown)
+}
+
+// updateBorder rebuilds a border container from a border layout's edges and center
+func (r *NativeRenderer) updateBorder(border *fyne.Container, l *layout.BorderLayout) {
+	nativeLayout := &nativeBorderLayout{
+		spacing: float32(l.Spacing()),
+		padding: float32(l.Padding()),
+	}
+
+	var objects []fyne.CanvasObject
+	var shown []shared.Component
+	edge := func(c shared.Component) fyne.CanvasObject {
+		if c == nil {
+			return nil
+		}
+		object := r.Object(c)
+		objects = append(objects, object)
+		shown = append(shown, c)
+		return object
+	}
+	nativeLayout.top = edge(l.Top())
+	nativeLayout.bottom = edge(l.Bottom())
+	nativeLayout.left = edge(l.Left())
+	nativeLayout.right = edge(l.Right())
+	for _, child := range l.Center() {
+		if child != nil {
+			objects = append(objects, r.Object(child))
+			shown = append(shown, child)
+		}
+	}
+	border.Layout = nativeLayout
+	border.Objects = objects
+	border.Refresh()
+	r.show(l, shown)
+}
+
+// textSizeName maps a font size in pixels onto the closest Fyne theme text size
+func textSizeName(size int) fyne.ThemeSizeName {
+	switch {
+	case size >= 20:
+		return theme.SizeNameHeadingText
+	case size >= 16:
+		return theme.SizeNameSubHeadingText
+	case size <= 12:
+		return theme.SizeNameCaptionText
+	default:
+		return theme.SizeNameText
+	}
+}
+
+// nativeBoxLayout arranges objects in a row or column with fixed spacing and
+// padding, matching the geometry of StackLayout and FlexLayout
+type nativeBoxLayout struct {
+	horizontal bool
+	spacing    float32
+	padding    float32
+}
+
+// MinSize returns the size needed to fit every visible object
+func (l *nativeBoxLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	var width, height float32
+	visible := 0
+	for _, object := range objects {
+		if !object.Visible() {
+			continue
+		}
+		min := object.MinSize()
+		if l.horizontal {
+			width += min.Width
+			height = maxFloat(height, min.Height)
+		} else {
+			height += min.Height
+			width = maxFloat(width, min.Width)
+		}
+		visible++
+	}
+
+	if visible > 1 {
+		gaps := l.spacing * float32(visible-1)
+		if l.horizontal {
+			width += gaps
+		} else {
+			height += gaps
+		}
+	}
+	return fyne.NewSize(width+2*l.padding, height+2*l.padding)
+}
+
+// Layout places the objects one after another, stretching them across the box
+func (l *nativeBoxLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	x, y := l.padding, l.padding
+	inner := fyne.NewSize(size.Width-2*l.padding, size.Height-2*l.padding)
+	for _, object := range objects {
+		if !object.Visible() {
+			continue
+		}
+		min := object.MinSize()
+		object.Move(fyne.NewPos(x, y))
+		if l.horizontal {
+			object.Resize(fyne.NewSize(min.Width, inner.Height))
+			x += min.Width + l.spacing
+		} else {
+			object.Resize(fyne.NewSize(inner.Width, min.Height))
+			y += min.Height + l.spacing
+		}
+	}
+}
+
+// maxFloat returns the larger of two values
+func maxFloat(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// errNoNativeWindow is returned when a dialog is requested before any window is shown
+var errNoNativeWindow = errors.New("no native window to show the dialog in")
+
+// ShowDialog displays a dialog with the given title, message, and buttons.
+// It blocks until a button is clicked and returns its index.
+func (r *NativeRenderer) ShowDialog(title, message string, buttons []string) int {
+	response, _ := r.ShowDialogContext(context.Background(), title, message, buttons)
+	return response
+}
+
+// ShowDialogContext displays a dialog and waits until a button is clicked or the
+// context is done. It returns the index of the clicked button, or -1 and an
+// error if the dialog was closed without an answer.
+func (r *NativeRenderer) ShowDialogContext(ctx context.Context, title, message string, buttons []string) (int, error) {
+	if len(buttons) == 0 {
+		buttons = []string{"OK"}
+	}
+
+	response := -1
+	err := r.runDialog(ctx, func(parent fyne.Window) dialog.Dialog {
+		d := dialog.NewCustomWithoutButtons(title, widget.NewLabel(message), parent)
+		actions := make([]fyne.CanvasObject, len(buttons))
+		for i, text := range buttons {
+			index := i
+			actions[i] = widget.NewButton(text, func() {
+				response = index
+				d.Hide()
+			})
+		}
+		d.SetButtons(actions)
+		return d
+	})
+	if err != nil {
+		return -1, err
+	}
+	if response < 0 {
+		return -1, ErrDialogClosed
+	}
+	return response, nil
+}
+
+// ConfirmContext asks a yes/no question and reports whether it was confirmed
+func (r *NativeRenderer) ConfirmContext(ctx context.Context, title, message string) (bool, error) {
+	confirmed := false
+	err := r.runDialog(ctx, func(parent fyne.Window) dialog.Dialog {
+		return dialog.NewConfirm(title, message, func(ok bool) {
+			confirmed = ok
+		}, parent)
+	})
+	return confirmed, err
+}
+
+// ShowErrorContext displays an error and waits until it is dismissed
+func (r *NativeRenderer) ShowErrorContext(ctx context.Context, err error) error {
+	return r.runDialog(ctx, func(parent fyne.Window) dialog.Dialog {
+		return dialog.NewError(err, parent)
+	})
+}
+
+// PromptContext asks for a line of text. It returns the text and whether OK was clicked.
+func (r *NativeRenderer) PromptContext(ctx context.Context, title, message, placeholder string) (string, bool, error) {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder(placeholder)
+
+	confirmed := false
+	err := r.runDialog(ctx, func(parent fyne.Window) dialog.Dialog {
+		items := []*widget.FormItem{widget.NewFormItem(message, entry)}
+		return dialog.NewForm(title, "OK", "Cancel", items, func(ok bool) {
+			confirmed = ok
+		}, parent)
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return entry.Text, confirmed, nil
+}
+
+// runDialog builds and shows a dialog on the UI thread, parented to the active
+// window, and blocks until it is closed or the context is done. It must not be
+// called from the UI thread; handlers of user events already run off it.
+func (r *NativeRenderer) runDialog(ctx context.Context, build func(parent fyne.Window) dialog.Dialog) error {
+	parent := r.activeWindow()
+	if parent == nil {
+		return errNoNativeWindow
+	}
+
+	closed := make(chan struct{})
+	var d dialog.Dialog
+	fyne.DoAndWait(func() {
+		d = build(parent)
+		d.SetOnClosed(func() {
+			close(closed)
+		})
+		d.Show()
+	})
+
+	select {
+	case <-closed:
+		return nil
+	case <-ctx.Done():
+		fyne.Do(d.Hide)
+		return ctx.Err()
+	}
+}
+
+// activeWindow returns the window dialogs are shown in: the focused window if
+// there is one, otherwise the first window
+func (r *NativeRenderer) activeWindow() fyne.Window {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.windows) == 0 {
+		return nil
+	}
+	for _, window := range r.windows {
+		if window.Canvas().Focused() != nil {
+			return window
+		}
+	}
+	return r.windows[0]
+}
@@ -0,0 +1,178 @@
+//go:build ci
+
+package gonic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"gonic/internal"
+	"gonic/shared"
+)
+
+// newTestNativeRenderer creates a native renderer on Fyne's test driver,
+// which needs no display
+func newTestNativeRenderer(t *testing.T) *NativeRenderer {
+	t.Helper()
+	fyneApp := test.NewApp()
+	t.Cleanup(fyneApp.Quit)
+	return NewNativeRenderer(internal.NewFyneRendererWithApp(fyneApp))
+}
+
+// waitFor fails the test unless cond becomes true within a second. Handlers
+// and the widget updates they cause run on goroutines of their own.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// returnsSoon fails the test unless fn returns within a second
+func returnsSoon(t *testing.T, what string, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("%s blocked", what)
+	}
+}
+
+func TestNativeRendererSyncsWidgets(t *testing.T) {
+	r := newTestNativeRenderer(t)
+
+	label := NewLabel("Hello")
+	button := NewButton("Click", nil)
+	caption := NewLabel("Caption")
+	row := NewFlexLayout()
+	row.Add(caption)
+	stack := NewStackLayout()
+	stack.Add(label, button, row)
+	window := NewWindow("Test", 320, 240)
+	window.SetContent(stack)
+	r.ShowWindow(window)
+
+	labelWidget := r.Object(label).(*widget.Label)
+	buttonWidget := r.Object(button).(*widget.Button)
+	captionWidget := r.Object(caption).(*widget.Label)
+	if labelWidget.Text != "Hello" || buttonWidget.Text != "Click" || captionWidget.Text != "Caption" {
+		t.Fatalf("widgets show %q, %q and %q", labelWidget.Text, buttonWidget.Text, captionWidget.Text)
+	}
+	if objects := r.Object(stack).(*fyne.Container).Objects; len(objects) != 3 {
+		t.Fatalf("stack holds %d objects, want 3", len(objects))
+	}
+
+	label.SetText("Changed")
+	button.SetText("Go")
+	button.SetDisabled(true)
+	caption.SetText("Updated")
+	waitFor(t, "the label text", func() bool { return labelWidget.Text == "Changed" })
+	waitFor(t, "the button text", func() bool { return buttonWidget.Text == "Go" && buttonWidget.Disabled() })
+	waitFor(t, "the caption text", func() bool { return captionWidget.Text == "Updated" })
+}
+
+func TestNativeHandlersRunOffUIThread(t *testing.T) {
+	r := newTestNativeRenderer(t)
+
+	// A handler opening a dialog blocks until the dialog is answered, here
+	// until the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	answered := make(chan error, 1)
+	checkbox := NewCheckbox("Agree", func(bool) {
+		_, err := r.ShowDialogContext(ctx, "Sure?", "Really agree?", []string{"No", "Yes"})
+		answered <- err
+	})
+	input := NewTextInput("")
+	stack := NewStackLayout()
+	stack.Add(checkbox, input)
+	window := NewWindow("Test", 320, 240)
+	window.SetContent(stack)
+	fyneWindow := r.ShowWindow(window)
+
+	check := r.Object(checkbox).(*widget.Check)
+	returnsSoon(t, "tapping the checkbox", func() {
+		test.Tap(check)
+	})
+	waitFor(t, "the dialog", func() bool { return fyneWindow.Canvas().Overlays().Top() != nil })
+	if !checkbox.Checked() {
+		t.Fatal("checkbox not checked while its handler runs")
+	}
+
+	cancel()
+	select {
+	case err := <-answered:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("dialog returned %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("dialog not closed by the context")
+	}
+	waitFor(t, "the checkbox to stay checked", func() bool { return check.Checked })
+
+	// Typing reaches the input in order, although every change is handled
+	// off the UI thread
+	entry := r.Object(input).(*widget.Entry)
+	returnsSoon(t, "typing", func() {
+		test.Type(entry, "hello")
+	})
+	waitFor(t, "the typed text", func() bool { return input.Value() == "hello" })
+	waitFor(t, "the entry text", func() bool { return entry.Text == "hello" })
+}
+
+func TestNativeRendererReleasesHiddenComponents(t *testing.T) {
+	r := newTestNativeRenderer(t)
+	kept := func(c shared.Component) bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		_, built := r.objects[c]
+		_, watched := r.unwatch[c]
+		return built && watched
+	}
+
+	detail := NewLabel("Detail")
+	page := NewStackLayout()
+	page.Add(detail)
+	root := NewStackLayout()
+	navigator := NewNavigator("Root", root)
+	content := NewStackLayout()
+	content.Add(navigator)
+	window := NewWindow("Test", 320, 240)
+	window.SetContent(content)
+	r.ShowWindow(window)
+
+	navigator.Push("Detail", page)
+	waitFor(t, "the detail page", func() bool { return kept(page) && kept(detail) })
+
+	// Popped pages are no longer shown, so their objects are dropped and
+	// their components no longer watched
+	navigator.Pop()
+	waitFor(t, "the detail page to be released", func() bool { return !kept(page) && !kept(detail) })
+	if !kept(root) || !kept(navigator) || !kept(content) {
+		t.Fatal("components still shown were released")
+	}
+	detail.SetText("Changed")
+
+	// Pages shown again are built again
+	navigator.Push("Detail", page)
+	waitFor(t, "the detail page to be shown again", func() bool { return kept(page) && kept(detail) })
+	label := r.Object(detail).(*widget.Label)
+	if label.Text != "Changed" {
+		t.Fatalf("label shows %q, want Changed", label.Text)
+	}
+}
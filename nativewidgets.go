@@ -0,0 +1,614 @@
+package gonic
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"gonic/components"
+	"gonic/layout"
+	"gonic/shared"
+)
+
+// nativeTable displays a table component as a Fyne table with a pager below it
+type nativeTable struct {
+	widget.BaseWidget
+	table   *components.Table
+	grid    *widget.Table
+	pager   *fyne.Container
+	status  *widget.Label
+	prev    *widget.Button
+	next    *widget.Button
+	content fyne.CanvasObject
+
+	// Snapshot of the component, only used on the UI thread
+	rows    []components.TableRow
+	columns []components.TableColumn
+	sortKey string
+	desc    bool
+	mode    components.SelectionMode
+}
+
+// newNativeTable creates the Fyne widgets for a table component
+func newNativeTable(table *components.Table) *nativeTable {
+	t := &nativeTable{table: table}
+	t.grid = widget.NewTableWithHeaders(t.size, t.createCell, t.updateCell)
+	t.grid.ShowHeaderColumn = false
+	t.grid.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewButton("", nil)
+	}
+	t.grid.UpdateHeader = t.updateHeader
+	t.grid.OnSelected = t.selected
+
+	// Loading a page may be slow, so handlers run on their own goroutine
+	t.prev = widget.NewButton("‹ Previous", func() {
+		go table.SetPage(table.Page() - 1)
+	})
+	t.next = widget.NewButton("Next ›", func() {
+		go table.SetPage(table.Page() + 1)
+	})
+	t.status = widget.NewLabel("")
+	t.pager = container.NewHBox(t.prev, t.status, t.next)
+	t.content = container.NewBorder(nil, t.pager, nil, nil, t.grid)
+
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+// CreateRenderer returns the renderer of the table and its pager
+func (t *nativeTable) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(t.content)
+}
+
+// update copies the state of the table component onto the widgets
+func (t *nativeTable) update() {
+	t.rows = t.table.Rows()
+	t.columns = t.table.Columns()
+	t.sortKey, t.desc = t.table.Sort()
+	t.mode = t.table.SelectionMode()
+
+	for i, column := range t.columns {
+		if column.Width > 0 {
+			t.grid.SetColumnWidth(i, float32(column.Width))
+		}
+	}
+
+	page, pages := t.table.Page(), t.table.PageCount()
+	if err := t.table.Err(); err != nil {
+		t.status.SetText(err.Error())
+	} else {
+		t.status.SetText(fmt.Sprintf("Page %d of %d", page+1, pages))
+	}
+	if page > 0 {
+		t.prev.Enable()
+	} else {
+		t.prev.Disable()
+	}
+	if page < pages-1 {
+		t.next.Enable()
+	} else {
+		t.next.Disable()
+	}
+	if t.table.PageSize() > 0 {
+		t.pager.Show()
+	} else {
+		t.pager.Hide()
+	}
+	t.grid.Refresh()
+}
+
+// size returns the number of rows and columns on the current page
+func (t *nativeTable) size() (rows int, columns int) {
+	return len(t.rows), len(t.columns)
+}
+
+// createCell creates a template cell
+func (t *nativeTable) createCell() fyne.CanvasObject {
+	return widget.NewLabel("")
+}
+
+// updateCell shows a cell value, highlighting selected rows
+func (t *nativeTable) updateCell(id widget.TableCellID, cell fyne.CanvasObject) {
+	label := cell.(*widget.Label)
+	if id.Row >= len(t.rows) || id.Col >= len(t.columns) {
+		label.SetText("")
+		return
+	}
+	row := t.rows[id.Row]
+	label.Importance = widget.MediumImportance
+	if t.table.IsSelected(row.Key) {
+		label.Importance = widget.HighImportance
+	}
+	label.SetText(t.columns[id.Col].FormatCell(row.Cells[t.columns[id.Col].Key]))
+}
+
+// updateHeader shows a column title, with a button to sort sortable columns
+func (t *nativeTable) updateHeader(id widget.TableCellID, header fyne.CanvasObject) {
+	button := header.(*widget.Button)
+	if id.Col < 0 || id.Col >= len(t.columns) {
+		button.SetText("")
+		button.OnTapped = nil
+		return
+	}
+
+	column := t.columns[id.Col]
+	title := column.Title
+	if column.Key == t.sortKey {
+		if t.desc {
+			title += " ▼"
+		} else {
+			title += " ▲"
+		}
+	}
+	button.SetText(title)
+	if column.Sortable {
+		button.OnTapped = func() {
+			go t.table.ToggleSort(column.Key)
+		}
+		button.Enable()
+	} else {
+		button.OnTapped = nil
+		button.Disable()
+	}
+}
+
+// selected forwards a clicked row to the table component. The cell is
+// unselected straight away, selected rows are highlighted instead.
+func (t *nativeTable) selected(id widget.TableCellID) {
+	t.grid.UnselectAll()
+	if id.Row < 0 || id.Row >= len(t.rows) || t.mode == components.NoSelection {
+		return
+	}
+	go t.table.Select(t.rows[id.Row].Key)
+}
+
+// nativeList displays a list component as a Fyne list. Fyne only creates
+// widgets for the items in view, reusing them while scrolling.
+type nativeList struct {
+	widget.List
+	list *components.List
+
+	// Number of items shown since the last update
+	shown int
+}
+
+// newNativeList creates the Fyne list for a list component
+func newNativeList(list *components.List) *nativeList {
+	l := &nativeList{list: list}
+	l.Length = list.Length
+	l.CreateItem = func() fyne.CanvasObject {
+		label := widget.NewLabel("")
+		label.Truncation = fyne.TextTruncateEllipsis
+		return label
+	}
+	l.UpdateItem = func(id widget.ListItemID, item fyne.CanvasObject) {
+		label := item.(*widget.Label)
+		label.Importance = widget.MediumImportance
+		if list.IsSelected(id) {
+			label.Importance = widget.HighImportance
+		}
+		label.SetText(list.Item(id))
+	}
+
+	// The item is unselected straight away, selected items are highlighted instead
+	l.OnSelected = func(id widget.ListItemID) {
+		l.UnselectAll()
+		if list.SelectionMode() != components.NoSelection {
+			go list.Select(id)
+		}
+	}
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+// update refreshes the list after its component changed, following the tail
+// unless the user scrolled away from it
+func (l *nativeList) update() {
+	following := l.list.FollowTail() && l.atTail()
+	l.shown = l.list.Length()
+	l.Refresh()
+	if following {
+		l.ScrollToBottom()
+	}
+}
+
+// atTail reports whether the last item shown is scrolled into view
+func (l *nativeList) atTail() bool {
+	if l.shown == 0 {
+		return true
+	}
+	padding := l.Theme().Size(theme.SizeNamePadding)
+	row := l.CreateItem().MinSize().Height + padding
+	content := row*float32(l.shown) - padding
+	return l.GetScrollOffset()+l.Size().Height >= content-row/2
+}
+
+// nativeTree displays a tree component as a Fyne tree. Children are shown
+// once the component has loaded them.
+type nativeTree struct {
+	widget.Tree
+	tree *components.Tree
+
+	// The last tapped node and when, to detect double taps
+	lastTap     string
+	lastTapTime time.Time
+}
+
+// newNativeTree creates the Fyne tree for a tree component
+func newNativeTree(tree *components.Tree) *nativeTree {
+	t := &nativeTree{tree: tree}
+	t.ChildUIDs = func(uid widget.TreeNodeID) []widget.TreeNodeID {
+		children, _ := tree.Children(uid)
+		ids := make([]widget.TreeNodeID, len(children))
+		for i, child := range children {
+			ids[i] = child.ID
+		}
+		return ids
+	}
+	t.IsBranch = func(uid widget.TreeNodeID) bool {
+		node, ok := tree.Node(uid)
+		return uid == "" || (ok && !node.Leaf)
+	}
+	t.CreateNode = func(bool) fyne.CanvasObject {
+		return widget.NewLabel("")
+	}
+	t.UpdateNode = func(uid widget.TreeNodeID, _ bool, object fyne.CanvasObject) {
+		label := object.(*widget.Label)
+		node, _ := tree.Node(uid)
+		selected, _ := tree.Selected()
+		label.Importance = widget.MediumImportance
+		if node.ID == selected.ID {
+			label.Importance = widget.HighImportance
+		}
+		label.SetText(strings.TrimSpace(node.Icon + " " + node.Label))
+	}
+
+	// Loading children may be slow, so handlers run on their own goroutine
+	t.OnBranchOpened = func(uid widget.TreeNodeID) {
+		go tree.Expand(uid)
+	}
+	t.OnBranchClosed = func(uid widget.TreeNodeID) {
+		go tree.Collapse(uid)
+	}
+	t.OnSelected = t.selected
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+// update opens and closes branches to match the tree component
+func (t *nativeTree) update() {
+	t.syncBranches("")
+	t.Refresh()
+}
+
+// syncBranches opens and closes the loaded branches below a node
+func (t *nativeTree) syncBranches(uid string) {
+	children, _ := t.tree.Children(uid)
+	for _, child := range children {
+		if child.Leaf {
+			continue
+		}
+		expanded := t.tree.IsExpanded(child.ID)
+		if expanded && !t.IsBranchOpen(child.ID) {
+			t.OpenBranch(child.ID)
+		} else if !expanded && t.IsBranchOpen(child.ID) {
+			t.CloseBranch(child.ID)
+		}
+		t.syncBranches(child.ID)
+	}
+}
+
+// selected forwards a tapped node to the tree component, reporting a second
+// tap on the same node within the double tap delay as a double click. The
+// node is unselected straight away, the selected node is highlighted instead.
+func (t *nativeTree) selected(uid widget.TreeNodeID) {
+	t.UnselectAll()
+
+	now := time.Now()
+	double := uid == t.lastTap && now.Sub(t.lastTapTime) < fyne.CurrentApp().Driver().DoubleTapDelay()
+	if double {
+		t.lastTap = ""
+		go t.tree.DoubleClick(uid)
+	} else {
+		t.lastTap, t.lastTapTime = uid, now
+		go t.tree.Select(uid)
+	}
+}
+
+// nativeNavigator displays a navigator component as a title bar with a back
+// button above the top page
+type nativeNavigator struct {
+	widget.BaseWidget
+	navigator *components.Navigator
+	back      *widget.Button
+	title     *widget.Label
+	page      *fyne.Container
+	content   fyne.CanvasObject
+}
+
+// newNativeNavigator creates the Fyne widgets for a navigator component
+func newNativeNavigator(navigator *components.Navigator) *nativeNavigator {
+	n := &nativeNavigator{navigator: navigator}
+	// Handlers run on their own goroutine so they may block, e.g. on a dialog
+	n.back = widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+		go navigator.Back()
+	})
+	n.title = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	n.page = container.NewStack()
+	n.content = container.NewBorder(container.NewHBox(n.back, n.title), nil, nil, nil, n.page)
+
+	n.ExtendBaseWidget(n)
+	return n
+}
+
+// CreateRenderer returns the renderer of the title bar and page
+func (n *nativeNavigator) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(n.content)
+}
+
+// update shows the top page of the navigator component, using the renderer
+// to build the objects of its content
+func (n *nativeNavigator) update(r *NativeRenderer) {
+	pages := n.navigator.Pages()
+	top := pages[len(pages)-1]
+	n.title.SetText(top.Title)
+	if len(pages) > 1 {
+		n.back.SetText(pages[len(pages)-2].Title)
+		n.back.Show()
+	} else {
+		n.back.Hide()
+	}
+
+	n.page.Objects = nil
+	var shown []shared.Component
+	if top.Content != nil {
+		n.page.Objects = []fyne.CanvasObject{r.Object(top.Content)}
+		shown = append(shown, top.Content)
+	}
+	n.page.Refresh()
+	r.show(n.navigator, shown)
+}
+
+// nativeImage displays an image component as a Fyne image. Fyne can't cover
+// an area with an image, so covering images are cropped to the widget's
+// aspect ratio instead.
+type nativeImage struct {
+	widget.BaseWidget
+	image   *components.Image
+	picture *canvas.Image
+
+	// The decoded image, only used on the UI thread
+	source image.Image
+	fit    components.ImageFit
+}
+
+// newNativeImage creates the Fyne image for an image component
+func newNativeImage(img *components.Image) *nativeImage {
+	n := &nativeImage{image: img, picture: &canvas.Image{}}
+	n.ExtendBaseWidget(n)
+	return n
+}
+
+// CreateRenderer returns the renderer of the image
+func (n *nativeImage) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(n.picture)
+}
+
+// Resize crops covering images again for the new size
+func (n *nativeImage) Resize(size fyne.Size) {
+	n.BaseWidget.Resize(size)
+	n.crop()
+}
+
+// update decodes the image component and sizes the widget
+func (n *nativeImage) update() {
+	source, err := n.image.Decode()
+	if err != nil {
+		log.Println("Failed to load image:", err)
+	}
+	n.source = source
+	n.fit = n.image.Fit()
+
+	n.picture.FillMode = canvas.ImageFillContain
+	if n.fit != components.FitContain {
+		n.picture.FillMode = canvas.ImageFillStretch
+	}
+	if width, height := n.image.Size(); width > 0 && height > 0 {
+		n.picture.SetMinSize(fyne.NewSize(float32(width), float32(height)))
+	} else if source != nil {
+		bounds := source.Bounds()
+		n.picture.SetMinSize(fyne.NewSize(float32(bounds.Dx()), float32(bounds.Dy())))
+	}
+	n.crop()
+}
+
+// crop shows the part of the image covering the widget, or all of it for
+// the other fit modes
+func (n *nativeImage) crop() {
+	n.picture.Image = n.source
+	size := n.Size()
+	sub, ok := n.source.(interface {
+		SubImage(image.Rectangle) image.Image
+	})
+	if n.fit == components.FitCover && ok && size.Width > 0 && size.Height > 0 {
+		bounds := n.source.Bounds()
+		width, height := bounds.Dx(), bounds.Dy()
+		// Cut off the sides of wide images, or the top and bottom of tall ones
+		if float32(width)*size.Height > float32(height)*size.Width {
+			width = int(float32(height) * size.Width / size.Height)
+		} else {
+			height = int(float32(width) * size.Height / size.Width)
+		}
+		x := bounds.Min.X + (bounds.Dx()-width)/2
+		y := bounds.Min.Y + (bounds.Dy()-height)/2
+		n.picture.Image = sub.SubImage(image.Rect(x, y, x+width, y+height))
+	}
+	n.picture.Refresh()
+}
+
+// nativeScroll displays a scroll container as a Fyne scroll container around
+// a box of its components
+type nativeScroll struct {
+	widget.BaseWidget
+	container *layout.ScrollContainer
+	content   *fyne.Container
+	scroll    *container.Scroll
+
+	// The last ScrollTo request scrolled to, only used on the UI thread
+	request int
+}
+
+// newNativeScroll creates the Fyne scroll container for a scroll container
+func newNativeScroll(c *layout.ScrollContainer) *nativeScroll {
+	s := &nativeScroll{container: c, content: container.New(&nativeBoxLayout{})}
+	s.scroll = container.NewScroll(s.content)
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// CreateRenderer returns the renderer of the scroll container
+func (s *nativeScroll) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(s.scroll)
+}
+
+// update rebuilds the content of the scroll container and scrolls to the
+// component last scrolled to, if it hasn't been yet
+func (s *nativeScroll) update(r *NativeRenderer) {
+	r.updateBox(s.content, s.container, false, &s.container.BaseLayout)
+	switch s.container.Direction() {
+	case layout.ScrollHorizontal:
+		s.scroll.Direction = container.ScrollHorizontalOnly
+	case layout.ScrollBoth:
+		s.scroll.Direction = container.ScrollBoth
+	default:
+		s.scroll.Direction = container.ScrollVerticalOnly
+	}
+	width, height := s.container.Size()
+	s.scroll.SetMinSize(fyne.NewSize(float32(width), float32(height)))
+	s.scroll.Refresh()
+
+	target, request := s.container.ScrollTarget()
+	if request == s.request {
+		return
+	}
+	s.request = request
+	if object, ok := r.built(target); ok {
+		// Targets may be nested in layouts, so compare window positions
+		driver := r.backend.App().Driver()
+		offset := driver.AbsolutePositionForObject(object).
+			Subtract(driver.AbsolutePositionForObject(s.content))
+		s.scroll.ScrollToOffset(offset)
+	}
+}
+
+// nativeSplit displays a split pane as a Fyne split container and reports
+// divider drags back to the split pane
+type nativeSplit struct {
+	widget.BaseWidget
+	pane     *layout.SplitPane
+	frame    *fyne.Container
+	split    *container.Split
+	leading  *nativePane
+	trailing *nativePane
+
+	// The ratio last shown or reported, only used on the UI thread
+	ratio float64
+}
+
+// newNativeSplit creates the Fyne split container for a split pane
+func newNativeSplit(pane *layout.SplitPane) *nativeSplit {
+	s := &nativeSplit{pane: pane, leading: newNativePane(), trailing: newNativePane()}
+	s.split = container.NewHSplit(s.leading, s.trailing)
+	s.frame = container.New(&nativeBorderLayout{}, s.split)
+	// Fyne doesn't report drags, but every drag resizes the leading pane
+	s.leading.onResize = s.resized
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// CreateRenderer returns the renderer of the split container
+func (s *nativeSplit) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(s.frame)
+}
+
+// resized reports the divider's new ratio when the user has dragged it
+func (s *nativeSplit) resized() {
+	if s.split.Offset == s.ratio {
+		return
+	}
+	s.ratio = s.split.Offset
+	// Handlers run on their own goroutine so they may block, e.g. on a dialog
+	go s.pane.MoveDivider(s.ratio)
+}
+
+// update shows the panes, direction and ratio of the split pane
+func (s *nativeSplit) update(r *NativeRenderer) {
+	s.frame.Layout = &nativeBorderLayout{padding: float32(s.pane.Padding())}
+	s.split.Horizontal = s.pane.Direction() == layout.Horizontal
+	minLeading, minTrailing := s.pane.MinSizes()
+	s.leading.update(r, s.pane.Leading(), minLeading, s.split.Horizontal)
+	s.trailing.update(r, s.pane.Trailing(), minTrailing, s.split.Horizontal)
+	r.show(s.pane, s.pane.Components())
+
+	s.ratio = s.pane.Ratio()
+	s.split.Offset = s.ratio
+	s.frame.Refresh()
+}
+
+// nativePane holds one side of a split pane, keeping it at least as large
+// as the split pane's minimum size for that side
+type nativePane struct {
+	widget.BaseWidget
+	content  *fyne.Container
+	min      fyne.Size
+	onResize func()
+}
+
+// newNativePane creates an empty pane
+func newNativePane() *nativePane {
+	p := &nativePane{content: container.NewStack()}
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+// CreateRenderer returns the renderer of the pane's content
+func (p *nativePane) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(p.content)
+}
+
+// MinSize returns the minimum size of the content or of the pane, whichever
+// is larger
+func (p *nativePane) MinSize() fyne.Size {
+	return p.BaseWidget.MinSize().Max(p.min)
+}
+
+// Resize resizes the pane and tells the split container about it
+func (p *nativePane) Resize(size fyne.Size) {
+	p.BaseWidget.Resize(size)
+	if p.onResize != nil {
+		p.onResize()
+	}
+}
+
+// update shows a component in the pane, with a minimum size along the
+// direction of the split pane
+func (p *nativePane) update(r *NativeRenderer, c shared.Component, min int, horizontal bool) {
+	p.content.Objects = nil
+	if c != nil {
+		p.content.Objects = []fyne.CanvasObject{r.Object(c)}
+	}
+	if horizontal {
+		p.min = fyne.NewSize(float32(min), 0)
+	} else {
+		p.min = fyne.NewSize(0, float32(min))
+	}
+	p.content.Refresh()
+}
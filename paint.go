@@ -0,0 +1,105 @@
+package gonic
+
+import (
+	"strings"
+
+	"gonic/components"
+	"gonic/internal"
+	"gonic/layout"
+	"gonic/shared"
+	"gonic/themes"
+)
+
+// Arrange computes the area of every component in the window's content at
+// the given size
+func (w *Window) Arrange(width, height int) *layout.Box {
+	if w.content == nil {
+		return nil
+	}
+	return layout.NewEngine(nil).Layout(w.content, width, height)
+}
+
+// ComponentAt returns the innermost component at a point of the window, or
+// nil if there is none
+func (w *Window) ComponentAt(x, y int) shared.Component {
+	box := w.Arrange(w.width, w.height).HitTest(x, y)
+	if box == nil {
+		return nil
+	}
+	return box.Component
+}
+
+// Paint lays out the window's content at the size of a render target and
+// draws it with a renderer backend. It returns the layout, e.g. to hit test
+// pointer events against what was drawn.
+func (w *Window) Paint(backend internal.RendererBackend, target internal.RenderTarget) *layout.Box {
+	theme := themes.GetTheme()
+	width, height := target.Size()
+
+	target.Clear()
+	backend.DrawRectangle(target, 0, 0, width, height, theme.BackgroundColor)
+	box := w.Arrange(width, height)
+	if box != nil {
+		paintBox(backend, target, theme, box, nil)
+	}
+	target.Present()
+	return box
+}
+
+// paintBox draws a box and the boxes nested in it. Backends can't clip what
+// they draw to a scroll container, so boxes that aren't wholly inside the
+// scroll containers around them are left out.
+func paintBox(backend internal.RendererBackend, target internal.RenderTarget, theme *themes.Theme, b *layout.Box, clip *layout.Rect) {
+	r := b.Rect
+	if clip != nil && (r.X < clip.X || r.Y < clip.Y || r.X+r.Width > clip.X+clip.Width || r.Y+r.Height > clip.Y+clip.Height) {
+		return
+	}
+	paintComponent(backend, target, theme, b)
+	if _, ok := b.Component.(*layout.ScrollContainer); ok {
+		clip = &r
+	}
+	for _, child := range b.Children {
+		paintBox(backend, target, theme, child, clip)
+	}
+}
+
+// paintComponent draws a single component in its box. Layouts only arrange
+// their children, so they draw nothing themselves.
+func paintComponent(backend internal.RendererBackend, target internal.RenderTarget, theme *themes.Theme, b *layout.Box) {
+	r := b.Rect
+	text := func(s string, size int, color string) {
+		backend.DrawText(target, s, r.X, r.Y, theme.FontFamily, size, color)
+	}
+
+	switch c := b.Component.(type) {
+	case shared.Layout, *components.Spacer:
+		// Nothing to draw
+	case *components.Label:
+		color := c.Color()
+		if color == "" {
+			color = theme.TextColor
+		}
+		text(c.Text(), c.FontSize(), color)
+	case *components.Button:
+		background, color := c.BackgroundColor(), c.Color()
+		if c.Disabled() {
+			background = theme.DisabledColor
+		}
+		backend.DrawRectangle(target, r.X, r.Y, r.Width, r.Height, background)
+		text(c.Text(), c.FontSize(), color)
+	case *components.TextInput:
+		backend.DrawRectangle(target, r.X, r.Y, r.Width, r.Height, theme.InputBackgroundColor)
+		if value := c.Value(); value != "" {
+			text(value, theme.BaseFontSize, theme.TextColor)
+		} else {
+			text(c.Placeholder(), theme.BaseFontSize, theme.DisabledColor)
+		}
+	case *components.ProgressBar:
+		backend.DrawRectangle(target, r.X, r.Y, r.Width, r.Height, theme.InputBorderColor)
+		backend.DrawRectangle(target, r.X, r.Y, int(float64(r.Width)*c.Value()), r.Height, theme.PrimaryColor)
+	default:
+		// Other components show the first line of their string representation
+		line := strings.SplitN(c.Render(), "\n", 2)[0]
+		text(line, theme.BaseFontSize, theme.TextColor)
+	}
+}
@@ -0,0 +1,56 @@
+package shared
+
+import (
+	"sync"
+)
+
+// ChangeListener is called after the state of a component has changed.
+type ChangeListener func(c Component)
+
+// Watchable is implemented by components that report changes to their state.
+type Watchable interface {
+	// Watch registers a listener and returns a function that removes it.
+	Watch(listener ChangeListener) (cancel func())
+}
+
+// Notifier keeps track of change listeners. It is meant to be embedded in components.
+type Notifier struct {
+	mu        sync.Mutex
+	listeners map[int]ChangeListener
+	nextID    int
+}
+
+// Watch registers a listener and returns a function that removes it.
+func (n *Notifier) Watch(listener ChangeListener) (cancel func()) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.listeners == nil {
+		n.listeners = make(map[int]ChangeListener)
+	}
+	id := n.nextID
+	n.nextID++
+	n.listeners[id] = listener
+
+	return func() {
+		n.mu.Lock()
+		delete(n.listeners, id)
+		n.mu.Unlock()
+	}
+}
+
+// Notify calls every registered listener with the given component.
+func (n *Notifier) Notify(c Component) {
+	n.mu.Lock()
+	listeners := make([]ChangeListener, 0, len(n.listeners))
+	for _, listener := range n.listeners {
+		listeners = append(listeners, listener)
+	}
+	n.mu.Unlock()
+
+	// Listeners are called without holding the lock so they may
+	// read the component or register further listeners
+	for _, listener := range listeners {
+		listener(c)
+	}
+}
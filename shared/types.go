@@ -38,6 +38,8 @@ const (
 	WebMode
 	// AutoMode selects the best available renderer
 	AutoMode
+	// HeadlessMode runs without a display and never initializes the native renderer
+	HeadlessMode
 )
 
 // Config holds global configuration for the Gonic framework
@@ -0,0 +1,999 @@
+package gonic
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonic/components"
+	"gonic/layout"
+	"gonic/shared"
+)
+
+// dispatchEvent delivers a browser event to the component that raised it
+func (s *Session) dispatchEvent(c shared.Component, event, value string) error {
+	switch c := c.(type) {
+	case *components.Button:
+		if event == "click" {
+			c.Click()
+			return nil
+		}
+	case *components.TextInput:
+		switch event {
+		case "change":
+			c.Change(value)
+			return nil
+		case "submit":
+			// Submits carry the latest text, which may not have been sent yet
+			c.Change(value)
+			c.Submit()
+			return nil
+		}
+	case *components.Checkbox:
+		if event == "change" {
+			c.Change(value == "on")
+			return nil
+		}
+	case *components.Switch:
+		if event == "change" {
+			c.Change(value == "on")
+			return nil
+		}
+	case *components.RadioGroup:
+		if event == "change" {
+			index, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid option %q", value)
+			}
+			c.Select(index)
+			return nil
+		}
+	case *components.Select:
+		if event == "change" {
+			c.Select(value)
+			return nil
+		}
+	case *components.ComboBox:
+		if event == "change" {
+			c.Change(value)
+			return nil
+		}
+	case *components.Slider:
+		if event == "change" {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid slider value %q", value)
+			}
+			c.Change(number)
+			return nil
+		}
+	case *components.Table:
+		switch event {
+		case "sort":
+			c.ToggleSort(value)
+			return nil
+		case "select":
+			c.Select(value)
+			return nil
+		case "page":
+			page, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid page %q", value)
+			}
+			c.SetPage(page)
+			return nil
+		}
+	case *components.List:
+		if event == "select" {
+			index, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid list item %q", value)
+			}
+			c.Select(index)
+			return nil
+		}
+	case *components.Tree:
+		switch event {
+		case "toggle":
+			c.Toggle(value)
+			return nil
+		case "select":
+			c.Select(value)
+			return nil
+		case "dblclick":
+			c.DoubleClick(value)
+			return nil
+		}
+	case *components.MenuItem:
+		if event == "click" {
+			c.Click()
+			return nil
+		}
+	case *components.Tabs:
+		if event == "select" {
+			index, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid tab %q", value)
+			}
+			c.SelectTab(index)
+			return nil
+		}
+	case *components.Navigator:
+		if event == "back" {
+			c.Back()
+			return nil
+		}
+	case *layout.SplitPane:
+		if event == "ratio" {
+			ratio, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid split ratio %q", value)
+			}
+			c.MoveDivider(ratio)
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported event %q for component %T", event, c)
+}
+
+// renderHTML renders a component tree to HTML markup for the web renderer
+func (s *Session) renderHTML(c shared.Component) template.HTML {
+	var b strings.Builder
+	s.writeComponent(&b, c)
+	return template.HTML(b.String())
+}
+
+// writeComponent writes the HTML markup for a single component and its children
+func (s *Session) writeComponent(b *strings.Builder, c shared.Component) {
+	switch c := c.(type) {
+	case *components.Label:
+		s.writeLabel(b, c)
+	case *components.Button:
+		s.writeButton(b, c)
+	case *components.Spacer:
+		s.writeSpacer(b, c)
+	case *components.TextInput:
+		s.writeTextInput(b, c)
+	case *components.Checkbox:
+		s.writeToggle(b, c, "gonic-checkbox", c.Label(), c.Checked(), c.Disabled())
+	case *components.Switch:
+		s.writeToggle(b, c, "gonic-switch", c.Label(), c.On(), c.Disabled())
+	case *components.RadioGroup:
+		s.writeRadioGroup(b, c)
+	case *components.Select:
+		s.writeSelect(b, c)
+	case *components.ComboBox:
+		s.writeComboBox(b, c)
+	case *components.ProgressBar:
+		s.writeProgressBar(b, c)
+	case *components.Slider:
+		s.writeSlider(b, c)
+	case *components.Table:
+		s.writeTable(b, c)
+	case *components.List:
+		s.writeList(b, c)
+	case *components.Tree:
+		id := s.componentID(c)
+		fmt.Fprintf(b, `<div id="%s" class="gonic-tree">`, id)
+		s.writeTreeNodes(b, c, id, "")
+		b.WriteString(`</div>`)
+	case *components.Tabs:
+		s.writeTabs(b, c)
+	case *components.Navigator:
+		s.writeNavigator(b, c)
+	case *components.Image:
+		s.writeImage(b, c)
+	case *components.Menu:
+		s.writeMenu(b, c)
+	case *components.MenuItem:
+		s.writeMenuItem(b, c)
+	case *layout.StackLayout:
+		s.writeLayout(b, c, "gonic-stack", layout.Vertical, &c.BaseLayout)
+	case *layout.FlexLayout:
+		s.writeFlex(b, c)
+	case *layout.GridLayout:
+		s.writeGrid(b, c)
+	case *layout.BorderLayout:
+		s.writeBorder(b, c)
+	case *layout.ScrollContainer:
+		s.writeScroll(b, c)
+	case *layout.SplitPane:
+		s.writeSplit(b, c)
+	case nil:
+		// Nothing to render
+	default:
+		// Unknown components fall back to their string representation
+		fmt.Fprintf(b, `<pre class="gonic-unknown">%s</pre>`, escape(c.Render()))
+	}
+}
+
+// writeLabel writes the markup for a label
+func (s *Session) writeLabel(b *strings.Builder, l *components.Label) {
+	style := fmt.Sprintf("font-size:%dpx;", l.FontSize()) + cssProperty("color", l.Color())
+	if l.Bold() {
+		style += "font-weight:bold;"
+	}
+	if l.Italic() {
+		style += "font-style:italic;"
+	}
+	fmt.Fprintf(b, `<div id="%s" class="gonic-label" style="%s">%s</div>`,
+		s.componentID(l), escape(style), escape(l.Text()))
+}
+
+// writeButton writes the markup for a button
+func (s *Session) writeButton(b *strings.Builder, btn *components.Button) {
+	width, height := btn.Size()
+	style := fmt.Sprintf("min-width:%dpx;height:%dpx;font-size:%dpx;", width, height, btn.FontSize()) +
+		cssProperty("color", btn.Color()) + cssProperty("background-color", btn.BackgroundColor())
+	disabled := ""
+	if btn.Disabled() {
+		disabled = " disabled"
+	}
+	id := s.componentID(btn)
+	// Buttons post a click event back to the renderer
+	fmt.Fprintf(b, `<form id="%s" class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="click">`+
+		`<button class="gonic-button" type="submit" style="%s"%s>%s</button></form>`,
+		id, id, escape(style), disabled, escape(btn.Text()))
+}
+
+// writeSpacer writes the markup for a spacer
+func (s *Session) writeSpacer(b *strings.Builder, spacer *components.Spacer) {
+	fmt.Fprintf(b, `<div id="%s" class="gonic-spacer" style="flex:0 0 %dpx;"></div>`, s.componentID(spacer), spacer.Size())
+}
+
+// writeTextInput writes the markup for a text input. Pressing Enter submits
+// the form, changes while typing are sent by the page script.
+func (s *Session) writeTextInput(b *strings.Builder, t *components.TextInput) {
+	id := s.componentID(t)
+	attrs := fmt.Sprintf(` class="gonic-input" name="value" placeholder="%s"`, escape(t.Placeholder()))
+	if t.MaxLength() > 0 {
+		attrs += fmt.Sprintf(` maxlength="%d"`, t.MaxLength())
+	}
+	if t.ReadOnly() {
+		attrs += " readonly"
+	}
+
+	fmt.Fprintf(b, `<form id="%s" class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="submit">`, id, id)
+	switch t.Kind() {
+	case components.MultiLineInput:
+		fmt.Fprintf(b, `<textarea%s rows="4">%s</textarea>`, attrs, escape(t.Value()))
+	case components.PasswordInput:
+		fmt.Fprintf(b, `<input type="password"%s value="%s">`, attrs, escape(t.Value()))
+	default:
+		fmt.Fprintf(b, `<input type="text"%s value="%s">`, attrs, escape(t.Value()))
+	}
+	b.WriteString(`</form>`)
+}
+
+// writeToggle writes the markup for a checkbox or a switch. The page script
+// posts a change event as soon as it is clicked.
+func (s *Session) writeToggle(b *strings.Builder, c shared.Component, class, label string, checked, disabled bool) {
+	id := s.componentID(c)
+	attrs := ""
+	if checked {
+		attrs += " checked"
+	}
+	if disabled {
+		attrs += " disabled"
+	}
+	fmt.Fprintf(b, `<form id="%s" class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="change">`+
+		`<label class="gonic-toggle"><input type="checkbox" class="gonic-choice %s" name="value"%s> %s</label></form>`,
+		id, id, class, attrs, escape(label))
+}
+
+// writeRadioGroup writes the markup for a radio group, posting the index of
+// the option the user picks
+func (s *Session) writeRadioGroup(b *strings.Builder, g *components.RadioGroup) {
+	id := s.componentID(g)
+	disabled := ""
+	if g.Disabled() {
+		disabled = " disabled"
+	}
+	fmt.Fprintf(b, `<form id="%s" class="gonic-event gonic-radio-group" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="change">`, id, id)
+	selected := g.Selected()
+	for i, option := range g.Options() {
+		checked := ""
+		if i == selected {
+			checked = " checked"
+		}
+		fmt.Fprintf(b, `<label class="gonic-toggle"><input type="radio" class="gonic-choice" name="value" value="%d"%s%s> %s</label>`,
+			i, checked, disabled, escape(option))
+	}
+	b.WriteString(`</form>`)
+}
+
+// writeSelect writes the markup for a dropdown, posting the value of the
+// option the user picks
+func (s *Session) writeSelect(b *strings.Builder, sel *components.Select) {
+	id := s.componentID(sel)
+	disabled := ""
+	if sel.Disabled() {
+		disabled = " disabled"
+	}
+	fmt.Fprintf(b, `<form id="%s" class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="change">`+
+		`<select class="gonic-choice gonic-select" name="value"%s>`, id, id, disabled)
+
+	selected, ok := sel.Selected()
+	if !ok {
+		// A hidden placeholder shows until an option is picked
+		fmt.Fprintf(b, `<option value="" disabled selected hidden>%s</option>`, escape(sel.Placeholder()))
+	}
+	for _, option := range sel.Options() {
+		attr := ""
+		if ok && option.Value == selected.Value {
+			attr = " selected"
+		}
+		fmt.Fprintf(b, `<option value="%s"%s>%s</option>`, escape(option.Value), attr, escape(option.Label))
+	}
+	b.WriteString(`</select></form>`)
+}
+
+// writeComboBox writes the markup for a combo box: a text field whose
+// suggestions are listed in a datalist
+func (s *Session) writeComboBox(b *strings.Builder, c *components.ComboBox) {
+	id := s.componentID(c)
+	disabled := ""
+	if c.Disabled() {
+		disabled = " disabled"
+	}
+	fmt.Fprintf(b, `<form id="%s" class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="change">`+
+		`<input type="text" class="gonic-input" name="value" list="%s-options" placeholder="%s" value="%s" autocomplete="off"%s>`,
+		id, id, id, escape(c.Placeholder()), escape(c.Text()), disabled)
+	fmt.Fprintf(b, `<datalist id="%s-options">`, id)
+	for _, option := range c.Options() {
+		fmt.Fprintf(b, `<option value="%s"></option>`, escape(option.Label))
+	}
+	b.WriteString(`</datalist></form>`)
+}
+
+// writeProgressBar writes the markup for a progress bar. Leaving out the
+// value makes the browser show an indeterminate bar.
+func (s *Session) writeProgressBar(b *strings.Builder, p *components.ProgressBar) {
+	value := ""
+	if !p.Indeterminate() {
+		value = fmt.Sprintf(` value="%s"`, formatFloat(p.Value()))
+	}
+	fmt.Fprintf(b, `<progress id="%s" class="gonic-progress" max="1"%s></progress>`, s.componentID(p), value)
+}
+
+// writeSlider writes the markup for a slider. The page script sends its value
+// once the user stops moving it.
+func (s *Session) writeSlider(b *strings.Builder, slider *components.Slider) {
+	id := s.componentID(slider)
+	min, max := slider.Range()
+	step := "any"
+	if slider.Step() > 0 {
+		step = formatFloat(slider.Step())
+	}
+	disabled := ""
+	if slider.Disabled() {
+		disabled = " disabled"
+	}
+	fmt.Fprintf(b, `<form id="%s" class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="change">`+
+		`<input type="range" class="gonic-input gonic-slider" name="value" min="%s" max="%s" step="%s" value="%s"%s></form>`,
+		id, id, formatFloat(min), formatFloat(max), step, formatFloat(slider.Value()), disabled)
+}
+
+// writeTable writes the markup for a table. Sortable headers, row selection
+// and page buttons each post their own event.
+func (s *Session) writeTable(b *strings.Builder, t *components.Table) {
+	id := s.componentID(t)
+	columns := t.Columns()
+	mode := t.SelectionMode()
+	sortKey, descending := t.Sort()
+
+	fmt.Fprintf(b, `<div id="%s" class="gonic-table"><table><thead><tr>`, id)
+	if mode != components.NoSelection {
+		b.WriteString(`<th class="gonic-table-select"></th>`)
+	}
+	for _, column := range columns {
+		style := ""
+		if column.Width > 0 {
+			style = fmt.Sprintf(` style="width:%dpx;"`, column.Width)
+		}
+		fmt.Fprintf(b, `<th%s>`, style)
+		if column.Sortable {
+			title := column.Title
+			if column.Key == sortKey {
+				if descending {
+					title += " ▼"
+				} else {
+					title += " ▲"
+				}
+			}
+			s.writeEventButton(b, id, "sort", column.Key, "gonic-table-sort", title, false)
+		} else {
+			b.WriteString(escape(column.Title))
+		}
+		b.WriteString(`</th>`)
+	}
+	b.WriteString(`</tr></thead><tbody>`)
+
+	span := len(columns)
+	if mode != components.NoSelection {
+		span++
+	}
+	rows := t.Rows()
+	if err := t.Err(); err != nil {
+		fmt.Fprintf(b, `<tr><td class="gonic-table-message" colspan="%d">%s</td></tr>`, span, escape(err.Error()))
+	} else if len(rows) == 0 {
+		fmt.Fprintf(b, `<tr><td class="gonic-table-message" colspan="%d">No rows</td></tr>`, span)
+	}
+	for _, row := range rows {
+		selected := t.IsSelected(row.Key)
+		if selected {
+			b.WriteString(`<tr class="gonic-row-selected">`)
+		} else {
+			b.WriteString(`<tr>`)
+		}
+		if mode != components.NoSelection {
+			kind, checked := "checkbox", ""
+			if mode == components.SingleSelection {
+				kind = "radio"
+			}
+			if selected {
+				checked = " checked"
+			}
+			fmt.Fprintf(b, `<td class="gonic-table-select"><form class="gonic-event" method="post" action="event">`+
+				`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="select">`+
+				`<input type="hidden" name="value" value="%s"><input type="%s" class="gonic-choice"%s></form></td>`,
+				id, escape(row.Key), kind, checked)
+		}
+		for _, column := range columns {
+			fmt.Fprintf(b, `<td>%s</td>`, escape(column.FormatCell(row.Cells[column.Key])))
+		}
+		b.WriteString(`</tr>`)
+	}
+	b.WriteString(`</tbody></table>`)
+
+	if t.PageSize() > 0 {
+		page, pages := t.Page(), t.PageCount()
+		b.WriteString(`<div class="gonic-pager">`)
+		s.writeEventButton(b, id, "page", strconv.Itoa(page-1), "gonic-button", "‹ Previous", page == 0)
+		fmt.Fprintf(b, `<span>Page %d of %d</span>`, page+1, pages)
+		s.writeEventButton(b, id, "page", strconv.Itoa(page+1), "gonic-button", "Next ›", page >= pages-1)
+		b.WriteString(`</div>`)
+	}
+	b.WriteString(`</div>`)
+}
+
+// listPageSize is the number of list items rendered before the page script
+// fetches the ones actually scrolled into view
+const listPageSize = 50
+
+// maxListItems caps the number of list items rendered for one request
+const maxListItems = 500
+
+// maxListHeight caps the height of the scrolling part of a list, well below
+// the tallest element browsers lay out
+const maxListHeight = 8000000
+
+// listScale returns how far a list scrolls per pixel of its items. Lists
+// taller than maxListHeight scroll less than their items are tall, and
+// the page script maps scroll positions back to items.
+func listScale(l *components.List) float64 {
+	total, view := l.Length()*l.ItemHeight(), l.Height()
+	if total <= maxListHeight || view >= maxListHeight {
+		return 1
+	}
+	return float64(maxListHeight-view) / float64(total-view)
+}
+
+// writeList writes the markup for a list: a scrolling box as tall as all of
+// its items, or maxListHeight, holding only the items around the visible part
+func (s *Session) writeList(b *strings.Builder, l *components.List) {
+	length, itemHeight := l.Length(), l.ItemHeight()
+	class := "gonic-list"
+	if l.SelectionMode() != components.NoSelection {
+		class += " gonic-list-selectable"
+	}
+	height := length * itemHeight
+	if height > maxListHeight {
+		height = maxListHeight
+	}
+	fmt.Fprintf(b, `<div id="%s" class="%s" data-item-height="%d" data-scale="%g" data-follow="%t" style="height:%dpx;">`,
+		s.componentID(l), class, itemHeight, listScale(l), l.FollowTail(), l.Height())
+	fmt.Fprintf(b, `<div style="height:%dpx;"></div>`, height)
+
+	// Start at the tail when following it, the page script scrolls there
+	start := 0
+	if l.FollowTail() && length > listPageSize {
+		start = length - listPageSize
+	}
+	s.writeListItems(b, l, start, listPageSize)
+	b.WriteString(`</div>`)
+}
+
+// writeListItems writes up to count list items from start, positioned where
+// they belong in the scrolling box
+func (s *Session) writeListItems(b *strings.Builder, l *components.List, start, count int) {
+	if count > maxListItems {
+		count = maxListItems
+	}
+	length, itemHeight := l.Length(), l.ItemHeight()
+	if start < 0 {
+		start = 0
+	}
+	end := start + count
+	if end > length {
+		end = length
+	}
+
+	top := int(float64(start*itemHeight) * listScale(l))
+	fmt.Fprintf(b, `<div class="gonic-list-items" style="top:%dpx;">`, top)
+	for i := start; i < end; i++ {
+		class := "gonic-list-item"
+		if l.IsSelected(i) {
+			class += " gonic-row-selected"
+		}
+		fmt.Fprintf(b, `<div class="%s" data-index="%d" style="height:%dpx;line-height:%dpx;">%s</div>`,
+			class, i, itemHeight, itemHeight, escape(l.Item(i)))
+	}
+	b.WriteString(`</div>`)
+}
+
+// writeTreeNodes writes the children of a tree node as a nested list. The page
+// script turns clicks on a label into select and double-click events.
+func (s *Session) writeTreeNodes(b *strings.Builder, t *components.Tree, id, parentID string) {
+	if err := t.Err(parentID); err != nil {
+		fmt.Fprintf(b, `<div class="gonic-tree-message">%s</div>`, escape(err.Error()))
+		return
+	}
+	children, _ := t.Children(parentID)
+	selected, _ := t.Selected()
+
+	b.WriteString(`<ul>`)
+	for _, node := range children {
+		b.WriteString(`<li><div class="gonic-tree-row">`)
+		if node.Leaf {
+			b.WriteString(`<span class="gonic-tree-toggle"></span>`)
+		} else if t.IsExpanded(node.ID) {
+			s.writeEventButton(b, id, "toggle", node.ID, "gonic-tree-toggle", "▾", false)
+		} else {
+			s.writeEventButton(b, id, "toggle", node.ID, "gonic-tree-toggle", "▸", false)
+		}
+		class := "gonic-tree-label"
+		if node.ID == selected.ID {
+			class += " gonic-row-selected"
+		}
+		fmt.Fprintf(b, `<span class="%s" data-node="%s">%s</span></div>`,
+			class, escape(node.ID), escape(strings.TrimSpace(node.Icon+" "+node.Label)))
+		if !node.Leaf && t.IsExpanded(node.ID) {
+			s.writeTreeNodes(b, t, id, node.ID)
+		}
+		b.WriteString(`</li>`)
+	}
+	b.WriteString(`</ul>`)
+}
+
+// writeImage writes an image whose bytes are served by the asset route. The
+// version in the URL makes browsers load the image again when it changes.
+func (s *Session) writeImage(b *strings.Builder, img *components.Image) {
+	id := s.componentID(img)
+	fit := "contain"
+	switch img.Fit() {
+	case components.FitCover:
+		fit = "cover"
+	case components.FitStretch:
+		fit = "fill"
+	}
+	style := "object-fit:" + fit + ";"
+	if width, height := img.Size(); width > 0 && height > 0 {
+		style += fmt.Sprintf("width:%dpx;height:%dpx;", width, height)
+	}
+	fmt.Fprintf(b, `<img id="%s" class="gonic-image" src="asset?id=%s&amp;v=%d" alt="%s" style="%s">`,
+		id, id, img.Version(), escape(img.Alt()), style)
+}
+
+// writeTabs writes the tab bar and the page of the selected tab
+func (s *Session) writeTabs(b *strings.Builder, t *components.Tabs) {
+	id := s.componentID(t)
+	fmt.Fprintf(b, `<div id="%s" class="gonic-tabs"><div class="gonic-tab-bar">`, id)
+	current := t.Current()
+	for i, tab := range t.Tabs() {
+		class := "gonic-tab"
+		if i == current {
+			class += " gonic-tab-active"
+		}
+		s.writeEventButton(b, id, "select", strconv.Itoa(i), class, tab.Title, false)
+	}
+	b.WriteString(`</div><div class="gonic-tab-page">`)
+	if tab, ok := t.CurrentTab(); ok && tab.Content != nil {
+		s.writeComponent(b, tab.Content)
+	}
+	b.WriteString(`</div></div>`)
+}
+
+// writeNavigator writes the title bar with its back button and the top page
+func (s *Session) writeNavigator(b *strings.Builder, n *components.Navigator) {
+	id := s.componentID(n)
+	fmt.Fprintf(b, `<div id="%s" class="gonic-navigator"><div class="gonic-nav-bar">`, id)
+	pages := n.Pages()
+	if len(pages) > 1 {
+		s.writeEventButton(b, id, "back", "", "gonic-nav-back", "‹ "+pages[len(pages)-2].Title, false)
+	}
+	top := pages[len(pages)-1]
+	fmt.Fprintf(b, `<span class="gonic-nav-title">%s</span></div><div class="gonic-nav-page">`, escape(top.Title))
+	if top.Content != nil {
+		s.writeComponent(b, top.Content)
+	}
+	b.WriteString(`</div></div>`)
+}
+
+// renderMenuBar renders the menu bar of a window
+func (s *Session) renderMenuBar(menus []*components.Menu) template.HTML {
+	if len(menus) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(`<nav class="gonic-menubar">`)
+	for _, menu := range menus {
+		s.writeMenu(&b, menu)
+	}
+	b.WriteString(`</nav>`)
+	return template.HTML(b.String())
+}
+
+// renderContextMenus renders the hidden context menus of a window. The page
+// script shows the menu of the component that is right-clicked.
+func (s *Session) renderContextMenus(menus map[shared.Component]*components.Menu) template.HTML {
+	// Menus shared by several components are written once, listing them all,
+	// so that element IDs stay unique
+	owners := make(map[*components.Menu][]string)
+	var order []*components.Menu
+	for c, menu := range menus {
+		if _, ok := owners[menu]; !ok {
+			order = append(order, menu)
+		}
+		owners[menu] = append(owners[menu], s.componentID(c))
+	}
+	sort.Slice(order, func(i, j int) bool { return s.componentID(order[i]) < s.componentID(order[j]) })
+
+	var b strings.Builder
+	for _, menu := range order {
+		ids := owners[menu]
+		sort.Strings(ids)
+		fmt.Fprintf(&b, `<div class="gonic-context-menu" data-for="%s">`, strings.Join(ids, " "))
+		s.writeMenu(&b, menu)
+		b.WriteString(`</div>`)
+	}
+	return template.HTML(b.String())
+}
+
+// writeMenu writes a menu title with its items, which are shown while the
+// menu is hovered or focused
+func (s *Session) writeMenu(b *strings.Builder, menu *components.Menu) {
+	fmt.Fprintf(b, `<div id="%s" class="gonic-menu"><button class="gonic-menu-title" type="button">%s</button><ul class="gonic-menu-items">`,
+		s.componentID(menu), escape(menu.Label()))
+	for _, item := range menu.Items() {
+		s.writeMenuItem(b, item)
+	}
+	b.WriteString(`</ul></div>`)
+}
+
+// writeMenuItem writes a menu item. Items with a shortcut carry it so that
+// the page script can click them when the keys are pressed.
+func (s *Session) writeMenuItem(b *strings.Builder, item *components.MenuItem) {
+	id := s.componentID(item)
+	if item.IsSeparator() {
+		fmt.Fprintf(b, `<li id="%s" class="gonic-menu-separator"></li>`, id)
+		return
+	}
+	if submenu := item.Submenu(); submenu != nil {
+		// The item's label replaces the title of the submenu
+		fmt.Fprintf(b, `<li id="%s" class="gonic-menu-item gonic-submenu"><span class="gonic-menu-button">`+
+			`<span class="gonic-menu-label">%s</span></span>`, id, escape(item.Label()))
+		s.writeMenu(b, submenu)
+		b.WriteString(`</li>`)
+		return
+	}
+
+	class, attr, check := "gonic-menu-item", "", ""
+	if item.Disabled() {
+		class += " gonic-menu-disabled"
+		attr = " disabled"
+	}
+	if item.Checked() {
+		check = "✓"
+	}
+	shortcut := item.Shortcut().String()
+	fmt.Fprintf(b, `<li id="%s" class="%s" data-shortcut="%s">`+
+		`<form class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="click">`+
+		`<button class="gonic-menu-button" type="submit"%s><span class="gonic-menu-check">%s</span>`+
+		`<span class="gonic-menu-label">%s</span><span class="gonic-menu-shortcut">%s</span></button></form></li>`,
+		id, class, shortcut, id, attr, check, escape(item.Label()), shortcut)
+}
+
+// writeEventButton writes a button that posts an event with a value for the
+// component with the given ID
+func (s *Session) writeEventButton(b *strings.Builder, id, event, value, class, label string, disabled bool) {
+	attr := ""
+	if disabled {
+		attr = " disabled"
+	}
+	fmt.Fprintf(b, `<form class="gonic-event" method="post" action="event">`+
+		`<input type="hidden" name="id" value="%s"><input type="hidden" name="event" value="%s">`+
+		`<input type="hidden" name="value" value="%s"><button class="%s" type="submit"%s>%s</button></form>`,
+		id, event, escape(value), class, attr, escape(label))
+}
+
+// writeLayout writes a flex container holding the layout's children
+func (s *Session) writeLayout(b *strings.Builder, c shared.Component, class string, direction layout.Direction, l *layout.BaseLayout) {
+	flexDirection := "column"
+	if direction == layout.Horizontal {
+		flexDirection = "row"
+	}
+	fmt.Fprintf(b, `<div id="%s" class="gonic-layout %s" style="flex-direction:%s;gap:%dpx;padding:%dpx;">`,
+		s.componentID(c), class, flexDirection, l.Spacing(), l.Padding())
+	for _, child := range l.Components() {
+		s.writeComponent(b, child)
+	}
+	b.WriteString(`</div>`)
+}
+
+// writeFlex writes a flex container holding the layout's children. Children
+// that grow, shrink or have a basis of their own are wrapped in an item
+// carrying their settings.
+func (s *Session) writeFlex(b *strings.Builder, l *layout.FlexLayout) {
+	flexDirection := "column"
+	if l.Direction() == layout.Horizontal {
+		flexDirection = "row"
+	}
+	style := fmt.Sprintf("flex-direction:%s;justify-content:%s;align-items:%s;gap:%dpx;padding:%dpx;",
+		flexDirection, cssJustify(l.Justify()), cssAlign(l.Alignment()), l.Spacing(), l.Padding())
+	if l.Wrap() {
+		style += "flex-wrap:wrap;align-content:flex-start;"
+	}
+
+	fmt.Fprintf(b, `<div id="%s" class="gonic-layout gonic-flex" style="%s">`, s.componentID(l), style)
+	for _, child := range l.Components() {
+		if child == nil {
+			continue
+		}
+		flex := l.Flex(child)
+		if flex == layout.DefaultFlexChild() {
+			s.writeComponent(b, child)
+			continue
+		}
+		basis := "auto"
+		if flex.Basis >= 0 {
+			basis = fmt.Sprintf("%dpx", flex.Basis)
+		}
+		fmt.Fprintf(b, `<div class="gonic-flex-item" style="flex:%s %s %s;">`,
+			formatFloat(flex.Grow), formatFloat(flex.Shrink), basis)
+		s.writeComponent(b, child)
+		b.WriteString(`</div>`)
+	}
+	b.WriteString(`</div>`)
+}
+
+// cssJustify maps a justification onto its CSS justify-content value
+func cssJustify(justify layout.Justify) string {
+	switch justify {
+	case layout.JustifyCenter:
+		return "center"
+	case layout.JustifyEnd:
+		return "flex-end"
+	case layout.JustifySpaceBetween:
+		return "space-between"
+	case layout.JustifySpaceAround:
+		return "space-around"
+	case layout.JustifySpaceEvenly:
+		return "space-evenly"
+	}
+	return "flex-start"
+}
+
+// cssColors matches the colors components may set in CSS: hex colors, color
+// functions such as rgb() and hsl(), and named colors. Anything else could
+// escape the property it is written in.
+var cssColors = regexp.MustCompile(`^(#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})|(rgb|rgba|hsl|hsla)\([0-9a-z.,%/+ -]*\)|[a-zA-Z]+)$`)
+
+// cssProperty returns a CSS declaration setting a property to a color, or
+// nothing if the color is empty or not a valid color, leaving the inherited
+// color in place
+func cssProperty(property, color string) string {
+	color = strings.TrimSpace(color)
+	if !cssColors.MatchString(color) {
+		return ""
+	}
+	return property + ":" + color + ";"
+}
+
+// cssAlign maps an alignment onto its CSS align-items value
+func cssAlign(alignment layout.Alignment) string {
+	switch alignment {
+	case layout.AlignStart:
+		return "flex-start"
+	case layout.AlignCenter:
+		return "center"
+	case layout.AlignEnd:
+		return "flex-end"
+	}
+	return "stretch"
+}
+
+// writeGrid writes a CSS grid holding the layout's children, each in a cell
+// spanning its rows and columns
+func (s *Session) writeGrid(b *strings.Builder, l *layout.GridLayout) {
+	var columns string
+	if n := l.Columns(); n > 0 {
+		tracks := make([]string, n)
+		for i, width := range l.ColumnWidths(n) {
+			switch width.Kind {
+			case layout.FixedColumn:
+				tracks[i] = formatFloat(width.Value) + "px"
+			case layout.FractionColumn:
+				// minmax keeps content from overflowing narrow fraction columns,
+				// as native grids do
+				tracks[i] = "minmax(min-content," + formatFloat(width.Value) + "fr)"
+			default:
+				tracks[i] = "auto"
+			}
+		}
+		columns = strings.Join(tracks, " ")
+	} else {
+		columns = fmt.Sprintf("repeat(auto-fill,minmax(%dpx,1fr))", l.MinColumnWidth())
+	}
+
+	rowGap, columnGap := l.Gaps()
+	fmt.Fprintf(b, `<div id="%s" class="gonic-layout gonic-grid" style="grid-template-columns:%s;row-gap:%dpx;column-gap:%dpx;padding:%dpx;">`,
+		s.componentID(l), columns, rowGap, columnGap, l.Padding())
+	spans := l.Spans()
+	for i, child := range l.Components() {
+		if child == nil || i >= len(spans) {
+			continue
+		}
+		fmt.Fprintf(b, `<div class="gonic-grid-cell" style="grid-row:span %d;grid-column:span %d;">`,
+			spans[i].Rows, spans[i].Columns)
+		s.writeComponent(b, child)
+		b.WriteString(`</div>`)
+	}
+	b.WriteString(`</div>`)
+}
+
+// writeBorder writes a CSS grid with the layout's edges around its center.
+// Only the edges that are set get a track, so empty edges leave no gap.
+func (s *Session) writeBorder(b *strings.Builder, l *layout.BorderLayout) {
+	top, bottom, left, right := l.Top(), l.Bottom(), l.Left(), l.Right()
+
+	middle := []string{"center"}
+	columns := []string{"minmax(0,1fr)"}
+	if left != nil {
+		middle = append([]string{"left"}, middle...)
+		columns = append([]string{"auto"}, columns...)
+	}
+	if right != nil {
+		middle = append(middle, "right")
+		columns = append(columns, "auto")
+	}
+	areas := []string{`'` + strings.Join(middle, " ") + `'`}
+	rows := []string{"1fr"}
+	if top != nil {
+		areas = append([]string{`'` + strings.Repeat("top ", len(middle)-1) + `top'`}, areas...)
+		rows = append([]string{"auto"}, rows...)
+	}
+	if bottom != nil {
+		areas = append(areas, `'`+strings.Repeat("bottom ", len(middle)-1)+`bottom'`)
+		rows = append(rows, "auto")
+	}
+
+	fmt.Fprintf(b, `<div id="%s" class="gonic-layout gonic-border" style="grid-template-areas:%s;grid-template-columns:%s;grid-template-rows:%s;gap:%dpx;padding:%dpx;">`,
+		s.componentID(l), escape(strings.Join(areas, " ")), strings.Join(columns, " "), strings.Join(rows, " "), l.Spacing(), l.Padding())
+	for _, edge := range []struct {
+		area      string
+		component shared.Component
+	}{{"top", top}, {"left", left}, {"right", right}, {"bottom", bottom}} {
+		if edge.component != nil {
+			fmt.Fprintf(b, `<div class="gonic-border-%s" style="grid-area:%s;">`, edge.area, edge.area)
+			s.writeComponent(b, edge.component)
+			b.WriteString(`</div>`)
+		}
+	}
+	fmt.Fprintf(b, `<div class="gonic-border-center" style="grid-area:center;gap:%dpx;">`, l.Spacing())
+	for _, child := range l.Center() {
+		s.writeComponent(b, child)
+	}
+	b.WriteString(`</div></div>`)
+}
+
+// writeScroll writes a scrolling area around a column of the container's
+// components. The browser scrolls it to the component last scrolled to when
+// the request number changes.
+func (s *Session) writeScroll(b *strings.Builder, l *layout.ScrollContainer) {
+	style := "overflow-x:hidden;overflow-y:auto;"
+	contentStyle := fmt.Sprintf("gap:%dpx;padding:%dpx;", l.Spacing(), l.Padding())
+	switch l.Direction() {
+	case layout.ScrollHorizontal:
+		style = "overflow-x:auto;overflow-y:hidden;"
+		contentStyle += "width:max-content;"
+	case layout.ScrollBoth:
+		style = "overflow:auto;"
+		contentStyle += "width:max-content;"
+	}
+	width, height := l.Size()
+	if width > 0 {
+		style += fmt.Sprintf("width:%dpx;", width)
+	}
+	if height > 0 {
+		style += fmt.Sprintf("height:%dpx;", height)
+	}
+
+	var target string
+	if c, request := l.ScrollTarget(); c != nil {
+		target = fmt.Sprintf(` data-scroll-to="%s" data-scroll-request="%d"`, s.componentID(c), request)
+	}
+	fmt.Fprintf(b, `<div id="%s" class="gonic-scroll" style="%s"%s><div class="gonic-layout gonic-scroll-content" style="%s">`,
+		s.componentID(l), style, target, contentStyle)
+	for _, child := range l.Components() {
+		s.writeComponent(b, child)
+	}
+	b.WriteString(`</div></div>`)
+}
+
+// writeSplit writes the panes of a split pane with a divider between them.
+// The leading pane takes its share of the space left by the divider, the
+// trailing pane the rest.
+func (s *Session) writeSplit(b *strings.Builder, l *layout.SplitPane) {
+	direction, flexDirection, size := "horizontal", "row", "width"
+	if l.Direction() != layout.Horizontal {
+		direction, flexDirection, size = "vertical", "column", "height"
+	}
+	minLeading, minTrailing := l.MinSizes()
+	ratio := formatFloat(l.Ratio())
+
+	fmt.Fprintf(b, `<div id="%s" class="gonic-split gonic-split-%s" style="flex-direction:%s;padding:%dpx;" data-ratio="%s" data-min-leading="%d" data-min-trailing="%d">`,
+		s.componentID(l), direction, flexDirection, l.Padding(), ratio, minLeading, minTrailing)
+	fmt.Fprintf(b, `<div class="gonic-split-pane" style="flex:0 0 calc((100%% - %dpx) * %s);min-%s:%dpx;">`,
+		layout.DividerSize, ratio, size, minLeading)
+	if leading := l.Leading(); leading != nil {
+		s.writeComponent(b, leading)
+	}
+	fmt.Fprintf(b, `</div><div class="gonic-split-divider" style="flex:0 0 %dpx;"></div>`, layout.DividerSize)
+	fmt.Fprintf(b, `<div class="gonic-split-pane" style="flex:1 1 0;min-%s:%dpx;">`, size, minTrailing)
+	if trailing := l.Trailing(); trailing != nil {
+		s.writeComponent(b, trailing)
+	}
+	b.WriteString(`</div></div>`)
+}
+
+// renderDialog renders a modal dialog whose buttons post their index back to the renderer
+func (s *Session) renderDialog(alert *AlertDialog) template.HTML {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<div id="%s" class="gonic-dialog-backdrop"><div class="gonic-dialog">`, escape(alert.ID))
+	fmt.Fprintf(&b, `<form class="gonic-event" method="post" action="alert"><input type="hidden" name="id" value="%s">`, escape(alert.ID))
+	fmt.Fprintf(&b, `<h3>%s</h3><p>%s</p>`, escape(alert.Title), escape(alert.Message))
+	if alert.Input {
+		fmt.Fprintf(&b, `<input class="gonic-dialog-input" type="text" name="value" placeholder="%s" autofocus>`, escape(alert.Placeholder))
+	}
+	// Buttons are written last to first and displayed reversed, so pressing
+	// Enter picks the last button, conventionally the affirmative one
+	b.WriteString(`<div class="gonic-dialog-buttons">`)
+	for i := len(alert.Buttons) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, `<button class="gonic-button" type="submit" name="response" value="%d">%s</button>`,
+			i, escape(alert.Buttons[i]))
+	}
+	b.WriteString(`</div></form></div></div>`)
+	return template.HTML(b.String())
+}
+
+// formatFloat formats a number for an HTML attribute without needless digits
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// escape escapes text for safe inclusion in HTML content and attributes
+func escape(s string) string {
+	return template.HTMLEscapeString(s)
+}
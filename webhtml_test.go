@@ -0,0 +1,100 @@
+package gonic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCSSProperty(t *testing.T) {
+	tests := []struct {
+		color string
+		want  string
+	}{
+		{"", ""},
+		{"red", "color:red;"},
+		{"RebeccaPurple", "color:RebeccaPurple;"},
+		{"#fff", "color:#fff;"},
+		{"#336699", "color:#336699;"},
+		{"#33669980", "color:#33669980;"},
+		{" #336699 ", "color:#336699;"},
+		{"rgb(51, 102, 153)", "color:rgb(51, 102, 153);"},
+		{"rgba(51 102 153 / 50%)", "color:rgba(51 102 153 / 50%);"},
+		{"hsl(210deg, 50%, 40%)", "color:hsl(210deg, 50%, 40%);"},
+		{"#12345", ""},
+		{"#ggg", ""},
+		{"red;background:url(x)", ""},
+		{"red\" onmouseover=\"alert(1)", ""},
+		{"rgb(1,2,3);position:fixed", ""},
+		{"expression(alert(1))", ""},
+		{"url(javascript:alert(1))", ""},
+	}
+
+	for _, tt := range tests {
+		if got := cssProperty("color", tt.color); got != tt.want {
+			t.Errorf("cssProperty(%q) = %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestLabelColors(t *testing.T) {
+	s := newSession("test", new(uint64))
+
+	// Labels inherit the color of the theme unless they set one
+	label := NewLabel("Plain")
+	var b strings.Builder
+	s.writeLabel(&b, label)
+	if strings.Contains(b.String(), "color:") {
+		t.Fatalf("label without a color sets one: %s", b.String())
+	}
+
+	label.SetColor("#336699")
+	b.Reset()
+	s.writeLabel(&b, label)
+	if !strings.Contains(b.String(), "color:#336699;") {
+		t.Fatalf("label doesn't show its color: %s", b.String())
+	}
+
+	label.SetColor("red;position:fixed")
+	b.Reset()
+	s.writeLabel(&b, label)
+	if strings.Contains(b.String(), "position") {
+		t.Fatalf("label writes an invalid color: %s", b.String())
+	}
+}
+
+func TestLongListsStayWithinBrowserLimits(t *testing.T) {
+	r := NewWebRenderer(0)
+	defer r.Close()
+	s := r.shared
+	list := NewList(10, func(index int) string { return fmt.Sprintf("item %d", index) })
+
+	var b strings.Builder
+	s.writeList(&b, list)
+	if html := b.String(); !strings.Contains(html, `<div style="height:240px;">`) || !strings.Contains(html, `data-scale="1"`) {
+		t.Fatalf("short list not shown at its full height:\n%s", html)
+	}
+
+	// A billion items would be far taller than browsers lay out
+	list.SetLength(1000000000)
+	b.Reset()
+	s.writeList(&b, list)
+	if html := b.String(); !strings.Contains(html, fmt.Sprintf(`<div style="height:%dpx;">`, maxListHeight)) {
+		t.Fatalf("long list not capped at %dpx:\n%s", maxListHeight, html[:200])
+	}
+
+	// The last items sit at the end of the capped height
+	start := list.Length() - 12
+	b.Reset()
+	s.writeListItems(&b, list, start, 20)
+	var top int
+	if _, err := fmt.Sscanf(b.String(), `<div class="gonic-list-items" style="top:%dpx;">`, &top); err != nil {
+		t.Fatal(err)
+	}
+	if bottom := top + 12*list.ItemHeight(); bottom < maxListHeight-list.Height() || bottom > maxListHeight+list.Height() {
+		t.Fatalf("last items end at %dpx, want them near %dpx", bottom, maxListHeight)
+	}
+	if !strings.Contains(b.String(), fmt.Sprintf(`data-index="%d"`, list.Length()-1)) {
+		t.Fatal("last item not rendered")
+	}
+}
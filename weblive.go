@@ -0,0 +1,177 @@
+package gonic
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"gonic/shared"
+)
+
+// liveEvent is a message pushed to connected browsers over Server-Sent Events
+type liveEvent struct {
+	name string
+	data []byte
+}
+
+// componentUpdate carries the new markup of a component that changed
+type componentUpdate struct {
+	ID   string `json:"id"`
+	HTML string `json:"html,omitempty"`
+}
+
+// clientBuffer is the number of events buffered per browser before it is dropped
+const clientBuffer = 64
+
+// updateDelay is how long component changes are collected before they are pushed
+const updateDelay = 50 * time.Millisecond
+
+// keepAliveInterval is how often an idle stream sends a comment to stay open
+const keepAliveInterval = 30 * time.Second
+
+// subscribe registers a browser for live events
+func (s *Session) subscribe() chan liveEvent {
+	events := make(chan liveEvent, clientBuffer)
+
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	// A closed session ends new streams straight away
+	if s.closed {
+		close(events)
+		return events
+	}
+	s.clients[events] = struct{}{}
+	return events
+}
+
+// unsubscribe removes a browser from live events
+func (s *Session) unsubscribe(events chan liveEvent) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	if _, ok := s.clients[events]; ok {
+		delete(s.clients, events)
+		close(events)
+	}
+}
+
+// hasClients reports whether any browser is listening for live events
+func (s *Session) hasClients() bool {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	return len(s.clients) > 0
+}
+
+// broadcast pushes an event to every browser connected to the session
+func (s *Session) broadcast(name string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Println("Failed to encode live event:", err)
+		return
+	}
+
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	for events := range s.clients {
+		select {
+		case events <- liveEvent{name: name, data: data}:
+		default:
+			// The browser is not keeping up. Dropping its stream makes it
+			// reconnect and reload, which brings it back in sync.
+			delete(s.clients, events)
+			close(events)
+		}
+	}
+}
+
+// componentChanged schedules the new markup of a component to be pushed to
+// connected browsers. Changes are collected for updateDelay so that a
+// component changing many times in a row, such as a progress bar, is only
+// rendered and sent once.
+func (s *Session) componentChanged(c shared.Component) {
+	if !s.hasClients() {
+		return
+	}
+
+	s.changedMu.Lock()
+	defer s.changedMu.Unlock()
+
+	if s.pending[c] {
+		return
+	}
+	s.pending[c] = true
+	s.changed = append(s.changed, c)
+	if len(s.changed) == 1 {
+		time.AfterFunc(updateDelay, s.pushChanges)
+	}
+}
+
+// pushChanges sends the markup of every component changed since the last push
+func (s *Session) pushChanges() {
+	s.changedMu.Lock()
+	changed := s.changed
+	s.changed = nil
+	s.pending = make(map[shared.Component]bool)
+	s.changedMu.Unlock()
+
+	for _, c := range changed {
+		// Components dropped by a full render since they changed aren't shown
+		if !s.shown(c) {
+			continue
+		}
+		s.broadcast("update", componentUpdate{
+			ID:   s.componentID(c),
+			HTML: string(s.renderHTML(c)),
+		})
+	}
+}
+
+// streamHandler streams live events to a browser
+func (r *WebRenderer) streamHandler(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	s := r.session(req)
+	if s == nil {
+		// The page reloads when its stream fails, starting a new session
+		r.sessionGone(w, req)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	events := s.subscribe()
+	defer s.unsubscribe(events)
+
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, event.data)
+			flusher.Flush()
+		case <-keepAlive.C:
+			s.touch()
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
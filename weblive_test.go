@@ -0,0 +1,124 @@
+package gonic
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// streamEvent is an event received from the live update stream
+type streamEvent struct {
+	name string
+	data string
+}
+
+// connectStream opens the live update stream of a server and returns the
+// events it receives. It returns once the stream is connected.
+func connectStream(t *testing.T, url string) <-chan streamEvent {
+	t.Helper()
+	resp, err := http.Get(url + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("stream returned %d", resp.StatusCode)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	if !lines.Scan() || lines.Text() != ": connected" {
+		t.Fatalf("stream started with %q", lines.Text())
+	}
+
+	events := make(chan streamEvent, 16)
+	go func() {
+		defer close(events)
+		var event streamEvent
+		for lines.Scan() {
+			line := lines.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && event.name != "":
+				events <- event
+				event = streamEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+// nextUpdates returns the component updates received until the stream has
+// been quiet for a while
+func nextUpdates(t *testing.T, events <-chan streamEvent) []componentUpdate {
+	t.Helper()
+	var updates []componentUpdate
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("stream closed")
+			}
+			if event.name != "update" {
+				continue
+			}
+			var update componentUpdate
+			if err := json.Unmarshal([]byte(event.data), &update); err != nil {
+				t.Fatal(err)
+			}
+			updates = append(updates, update)
+		case <-time.After(4 * updateDelay):
+			return updates
+		}
+	}
+}
+
+func TestChangesArePushedInBatches(t *testing.T) {
+	window, label, button, _ := newCounter()
+	r := NewWebRenderer(0)
+	r.SetWindows([]*Window{window})
+	server := httptest.NewServer(r.Handler())
+	defer server.Close()
+	defer r.Close()
+
+	// Components get their IDs when the page is rendered
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	events := connectStream(t, server.URL)
+
+	// Changes made in a row are sent once per component, with the latest state
+	go func() {
+		for i := 1; i <= 5; i++ {
+			label.SetText(strings.Repeat("tick ", i))
+		}
+		button.SetDisabled(true)
+	}()
+	updates := nextUpdates(t, events)
+	if len(updates) != 2 {
+		t.Fatalf("received %d updates, want one for the label and one for the button: %+v", len(updates), updates)
+	}
+	byID := map[string]string{updates[0].ID: updates[0].HTML, updates[1].ID: updates[1].HTML}
+	labelHTML, buttonHTML := byID[r.shared.componentID(label)], byID[r.shared.componentID(button)]
+	if strings.Count(labelHTML, "tick") != 5 {
+		t.Fatalf("label update doesn't show the latest text: %s", labelHTML)
+	}
+	if !strings.Contains(buttonHTML, " disabled") {
+		t.Fatalf("button update doesn't show it disabled: %s", buttonHTML)
+	}
+
+	// Later changes start a new batch
+	label.SetText("tock")
+	updates = nextUpdates(t, events)
+	if len(updates) != 1 || !strings.Contains(updates[0].HTML, "tock") {
+		t.Fatalf("received %+v, want the label showing tock", updates)
+	}
+}
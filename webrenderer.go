@@ -1,106 +1,312 @@
 package gonic
 
 import (
+	"context"
 	"fmt"
 	"html/template"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"gonic/components"
 )
 
 // WebRenderer provides a browser-based renderer for the Gonic framework
 type WebRenderer struct {
+	// dialogSeqs numbers dialogs across sessions. It is only used atomically
+	// and comes first to be 64-bit aligned on 32-bit platforms.
+	dialogSeqs uint64
+
 	port     int
+	basePath string
 	windows  []*Window
-	counter  Counter
-	alerts   map[string]AlertDialog
-	alertsMu sync.Mutex
+	mux      *http.ServeMux
+	muxOnce  sync.Once
+
+	// done is closed when the renderer is closed to stop background work
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// shared is the session used by every browser when sessions are disabled
+	shared *Session
+
+	// Per-browser sessions, enabled with EnableSessions
+	factory    SessionFactory
+	sessionTTL time.Duration
+	sessions   map[string]*Session
+	endHooks   []func(*Session)
+	expireOnce sync.Once
+	sessionsMu sync.Mutex
 }
 
+// shutdownTimeout is how long RunContext waits for requests to finish when stopping
+const shutdownTimeout = 5 * time.Second
+
+// themeCookie is the name of the cookie that remembers the page theme
+const themeCookie = "gonic_theme"
+
 // NewWebRenderer creates a new web renderer
 func NewWebRenderer(port int) *WebRenderer {
-	return &WebRenderer{
-		port:   port,
-		alerts: make(map[string]AlertDialog),
+	r := &WebRenderer{
+		port:     port,
+		basePath: "/",
+		done:     make(chan struct{}),
+		sessions: make(map[string]*Session),
 	}
+	r.shared = newSession("shared", &r.dialogSeqs)
+	return r
 }
 
-// Run starts the web renderer and displays all windows
-func (r *WebRenderer) Run(windows []*Window) {
+// SetBasePath sets the URL path the UI is served under, such as "/admin/ui/".
+// It must be called before Handler or Run.
+func (r *WebRenderer) SetBasePath(path string) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	r.basePath = path
+}
+
+// BasePath returns the URL path the UI is served under
+func (r *WebRenderer) BasePath() string {
+	return r.basePath
+}
+
+// SetWindows sets the windows displayed by the renderer
+func (r *WebRenderer) SetWindows(windows []*Window) {
 	r.windows = windows
+	r.shared.setWindows(windows)
+}
+
+// Handler returns an http.Handler serving the UI under the base path,
+// so it can be mounted in an existing server:
+//
+//	renderer.SetBasePath("/admin/ui/")
+//	mux.Handle("/admin/ui/", renderer.Handler())
+func (r *WebRenderer) Handler() http.Handler {
+	r.muxOnce.Do(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc(r.basePath, r.homeHandler)
+		mux.HandleFunc(r.basePath+"event", r.eventHandler)
+		mux.HandleFunc(r.basePath+"stream", r.streamHandler)
+		mux.HandleFunc(r.basePath+"theme", r.themeHandler)
+		mux.HandleFunc(r.basePath+"alert", r.alertHandler)
+		mux.HandleFunc(r.basePath+"list", r.listHandler)
+		mux.HandleFunc(r.basePath+"asset", r.assetHandler)
+		r.mux = mux
+	})
+	return r.mux
+}
+
+// ServeHTTP serves the UI, making the renderer itself an http.Handler
+func (r *WebRenderer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Handler().ServeHTTP(w, req)
+}
 
-	// Register handlers
-	http.HandleFunc("/", r.homeHandler)
-	http.HandleFunc("/increment", r.incrementHandler)
-	http.HandleFunc("/decrement", r.decrementHandler)
-	http.HandleFunc("/reset", r.resetHandler)
-	http.HandleFunc("/theme", r.themeHandler)
-	http.HandleFunc("/alert", r.alertHandler)
-
-	// Start the server
-	addr := fmt.Sprintf(":%d", r.port)
-	log.Fatal(http.ListenAndServe(addr, nil))
+// Run starts the web renderer and displays all windows
+func (r *WebRenderer) Run(windows []*Window) {
+	if err := r.RunContext(context.Background(), windows); err != nil {
+		log.Fatal(err)
+	}
 }
 
-// Counter holds a counter value
-type Counter struct {
-	Value int
+// RunContext serves the windows until the context is done, then shuts the
+// server down gracefully. It returns nil after a clean shutdown.
+func (r *WebRenderer) RunContext(ctx context.Context, windows []*Window) error {
+	r.SetWindows(windows)
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", r.port),
+		Handler: r.Handler(),
+	}
+	// Live streams and pending dialogs never go idle on their own
+	server.RegisterOnShutdown(r.Close)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		r.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; err != http.ErrServerClosed {
+		return err
+	}
+	return nil
 }
 
-// AlertDialog represents a dialog to display
-type AlertDialog struct {
-	Title    string
-	Message  string
-	Buttons  []string
-	Response int
-	Done     bool
+// Close disconnects every browser, dismisses pending dialogs, ends every
+// session and stops background work. Call it when shutting down a server the
+// handler is mounted in.
+func (r *WebRenderer) Close() {
+	r.closeOnce.Do(func() {
+		close(r.done)
+	})
+	r.shared.close()
+
+	r.sessionsMu.Lock()
+	ended := make([]*Session, 0, len(r.sessions))
+	for id, s := range r.sessions {
+		delete(r.sessions, id)
+		ended = append(ended, s)
+	}
+	r.sessionsMu.Unlock()
+
+	r.endSessions(ended)
 }
 
-// ShowDialog displays a dialog with the given title, message, and buttons
+// ShowDialog displays a dialog with the given title, message, and buttons.
+// It blocks until a button is clicked in a browser and returns its index, or
+// -1 straight away if no browser session exists or, without sessions, no
+// browser is connected.
 func (r *WebRenderer) ShowDialog(title, message string, buttons []string) int {
-	alertID := fmt.Sprintf("alert-%d", time.Now().UnixNano())
+	response, _ := r.ShowDialogContext(context.Background(), title, message, buttons)
+	return response
+}
 
-	r.alertsMu.Lock()
-	r.alerts[alertID] = AlertDialog{
-		Title:   title,
-		Message: message,
-		Buttons: buttons,
+// ShowDialogContext displays a dialog and waits until a button is clicked or the
+// context is done. It returns the index of the clicked button, or -1 and the
+// context's error if the dialog was dismissed by the context. It returns -1
+// and ErrNoSessions straight away if no browser session exists or, without
+// sessions, no browser is connected.
+//
+// When sessions are enabled the dialog is shown in every session and the first
+// answer wins; use Session.ShowDialogContext to ask a single session.
+func (r *WebRenderer) ShowDialogContext(ctx context.Context, title, message string, buttons []string) (int, error) {
+	alert, err := r.askSessions(ctx, func(seq uint64) *AlertDialog {
+		return newAlertDialog(seq, title, message, buttons)
+	})
+	if err != nil {
+		return -1, err
 	}
-	r.alertsMu.Unlock()
+	return alert.Response, nil
+}
 
-	// In a real implementation, we would wait for the response
-	// For now, just return 0
-	return 0
+// PromptContext asks for a line of text and waits until the dialog is answered
+// or the context is done. It returns the text and whether OK was clicked.
+func (r *WebRenderer) PromptContext(ctx context.Context, title, message, placeholder string) (string, bool, error) {
+	alert, err := r.askSessions(ctx, func(seq uint64) *AlertDialog {
+		return newPromptDialog(seq, title, message, placeholder)
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return alert.Value, alert.Response == 1, nil
+}
+
+// askSessions opens a dialog in every session and returns the first answer,
+// or ErrNoSessions straight away if there is no session to ask. The shared
+// session only counts while a browser is connected to it, as nothing else
+// would ever answer.
+func (r *WebRenderer) askSessions(ctx context.Context, newAlert func(seq uint64) *AlertDialog) (*AlertDialog, error) {
+	sessions := r.activeSessions()
+	if !r.sessionsEnabled() && !r.shared.hasClients() {
+		sessions = nil
+	}
+	if len(sessions) == 0 {
+		return nil, ErrNoSessions
+	}
+
+	// Closing the context dismisses the dialog in the sessions that didn't answer
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	answers := make(chan *AlertDialog, len(sessions))
+	errs := make(chan error, len(sessions))
+	for _, s := range sessions {
+		go func(s *Session) {
+			alert := newAlert(s.nextDialogSeq())
+			if err := s.openDialog(ctx, alert); err != nil {
+				errs <- err
+				return
+			}
+			answers <- alert
+		}(s)
+	}
+
+	for failed := 0; ; {
+		select {
+		case alert := <-answers:
+			return alert, nil
+		case err := <-errs:
+			// Give up once no session can answer any more
+			if failed++; failed == len(sessions) {
+				return nil, err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// webWindow holds the data needed to render a single window
+type webWindow struct {
+	Title        string
+	Width        int
+	Height       int
+	MenuBar      template.HTML
+	Content      template.HTML
+	ContextMenus template.HTML
 }
 
 // homeHandler handles the main page
 func (r *WebRenderer) homeHandler(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != r.basePath {
+		http.NotFound(w, req)
+		return
+	}
+
+	s := r.pageSession(w, req)
+	sessionWindows := s.Windows()
+
 	// Get the title from the first window
-	title := "Gonic Dashboard"
-	if len(r.windows) > 0 {
-		title = r.windows[0].title
+	title := "Gonic App"
+	if len(sessionWindows) > 0 {
+		title = sessionWindows[0].title
 	}
 
+	// Render the component tree of every window
+	windows := make([]webWindow, 0, len(sessionWindows))
+	s.beginRender()
+	for _, window := range sessionWindows {
+		windows = append(windows, webWindow{
+			Title:        window.title,
+			Width:        window.width,
+			Height:       window.height,
+			MenuBar:      s.renderMenuBar(window.menus),
+			Content:      s.renderHTML(window.content),
+			ContextMenus: s.renderContextMenus(window.contextMenus),
+		})
+	}
+	s.endRender()
+
 	// Create template data
 	data := struct {
-		Title       string
-		Counter     Counter
-		CurrentTime string
-		Theme       string
-		Windows     []*Window
+		Title   string
+		Theme   string
+		Windows []webWindow
+		Dialogs []template.HTML
 	}{
-		Title:       title,
-		Counter:     r.counter,
-		CurrentTime: time.Now().Format("January 2, 2006"),
-		Theme:       req.URL.Query().Get("theme"),
-		Windows:     r.windows,
-	}
-
-	// If theme is not specified, default to dark
-	if data.Theme == "" {
-		data.Theme = "dark"
+		Title:   title,
+		Theme:   r.theme(req),
+		Windows: windows,
+		Dialogs: s.pendingDialogs(),
 	}
 
 	// Parse template
@@ -117,49 +323,146 @@ func (r *WebRenderer) homeHandler(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-// incrementHandler handles incrementing the counter
-func (r *WebRenderer) incrementHandler(w http.ResponseWriter, req *http.Request) {
-	r.counter.Value++
-	http.Redirect(w, req, "/?theme="+req.URL.Query().Get("theme"), http.StatusSeeOther)
+// theme returns the page theme chosen by the browser, defaulting to dark
+func (r *WebRenderer) theme(req *http.Request) string {
+	if theme := req.URL.Query().Get("theme"); theme != "" {
+		return theme
+	}
+	if cookie, err := req.Cookie(themeCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	return "dark"
 }
 
-// decrementHandler handles decrementing the counter
-func (r *WebRenderer) decrementHandler(w http.ResponseWriter, req *http.Request) {
-	r.counter.Value--
-	http.Redirect(w, req, "/?theme="+req.URL.Query().Get("theme"), http.StatusSeeOther)
+// eventHandler routes an event from the browser to the component that raised it
+func (r *WebRenderer) eventHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s := r.session(req)
+	if s == nil {
+		r.sessionGone(w, req)
+		return
+	}
+	component := s.componentByID(req.FormValue("id"))
+	if component == nil {
+		http.Error(w, "unknown component", http.StatusNotFound)
+		return
+	}
+
+	if err := s.dispatchEvent(component, req.FormValue("event"), req.FormValue("value")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.finishEvent(w, req)
 }
 
-// resetHandler handles resetting the counter
-func (r *WebRenderer) resetHandler(w http.ResponseWriter, req *http.Request) {
-	r.counter.Value = 0
-	http.Redirect(w, req, "/?theme="+req.URL.Query().Get("theme"), http.StatusSeeOther)
+// listHandler renders the items of a list that are scrolled into view
+func (r *WebRenderer) listHandler(w http.ResponseWriter, req *http.Request) {
+	s := r.session(req)
+	if s == nil {
+		r.sessionGone(w, req)
+		return
+	}
+	list, ok := s.componentByID(req.FormValue("id")).(*components.List)
+	if !ok {
+		http.Error(w, "unknown list", http.StatusNotFound)
+		return
+	}
+
+	start, err := strconv.Atoi(req.FormValue("start"))
+	if err != nil {
+		http.Error(w, "invalid start", http.StatusBadRequest)
+		return
+	}
+	count, err := strconv.Atoi(req.FormValue("count"))
+	if err != nil {
+		http.Error(w, "invalid count", http.StatusBadRequest)
+		return
+	}
+
+	var b strings.Builder
+	s.writeListItems(&b, list, start, count)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	io.WriteString(w, b.String())
+}
+
+// assetHandler serves the bytes of an image
+func (r *WebRenderer) assetHandler(w http.ResponseWriter, req *http.Request) {
+	s := r.session(req)
+	if s == nil {
+		r.sessionGone(w, req)
+		return
+	}
+	img, ok := s.componentByID(req.FormValue("id")).(*components.Image)
+	if !ok {
+		http.Error(w, "unknown image", http.StatusNotFound)
+		return
+	}
+
+	data, contentType, err := img.Data()
+	if err != nil {
+		log.Println("Failed to load image:", err)
+		http.Error(w, "image unavailable", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	// The URL changes with the image, so browsers may keep it
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(data)
 }
 
 // themeHandler handles changing the theme
 func (r *WebRenderer) themeHandler(w http.ResponseWriter, req *http.Request) {
-	theme := req.URL.Query().Get("set")
-	http.Redirect(w, req, "/?theme="+theme, http.StatusSeeOther)
+	http.SetCookie(w, &http.Cookie{
+		Name:     themeCookie,
+		Value:    req.URL.Query().Get("set"),
+		Path:     r.basePath,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, req, r.basePath, http.StatusSeeOther)
 }
 
 // alertHandler handles alert responses
 func (r *WebRenderer) alertHandler(w http.ResponseWriter, req *http.Request) {
-	alertID := req.URL.Query().Get("id")
-	responseStr := req.URL.Query().Get("response")
+	alertID := req.FormValue("id")
+	responseStr := req.FormValue("response")
 
 	if alertID != "" && responseStr != "" {
-		response, _ := strconv.Atoi(responseStr)
-
-		r.alertsMu.Lock()
-		if alert, ok := r.alerts[alertID]; ok {
-			alert.Response = response
-			alert.Done = true
-			r.alerts[alertID] = alert
+		response, err := strconv.Atoi(responseStr)
+		if err != nil {
+			http.Error(w, "invalid response", http.StatusBadRequest)
+			return
 		}
-		r.alertsMu.Unlock()
+		s := r.session(req)
+		if s == nil {
+			r.sessionGone(w, req)
+			return
+		}
+		s.closeDialog(alertID, response, req.FormValue("value"))
+	}
+
+	r.finishEvent(w, req)
+}
+
+// finishEvent completes a request that delivered an event from the browser
+func (r *WebRenderer) finishEvent(w http.ResponseWriter, req *http.Request) {
+	// Live pages receive the resulting changes over the event stream
+	if req.Header.Get("X-Gonic-Live") != "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
 	}
 
-	// Redirect back to the main page
-	http.Redirect(w, req, "/?theme="+req.URL.Query().Get("theme"), http.StatusSeeOther)
+	// Redirect back to the page that raised the event
+	target := req.Referer()
+	if target == "" {
+		target = r.basePath
+	}
+	http.Redirect(w, req, target, http.StatusSeeOther)
 }
 
 // Web template for rendering the UI
@@ -172,7 +475,6 @@ const webTemplate = `<!DOCTYPE html>
     <style>
         body {
             font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, Helvetica, Arial, sans-serif;
-            text-align: center;
             padding: 40px;
             margin: 0;
             transition: background-color 0.3s, color 0.3s;
@@ -187,124 +489,900 @@ const webTemplate = `<!DOCTYPE html>
         h1 {
             font-size: 32px;
             font-weight: bold;
-            margin-bottom: 10px;
-        }
-        h2 {
-            font-size: 18px;
-            font-style: italic;
             margin-bottom: 30px;
-            color: {{if eq .Theme "dark"}}#adb5bd{{else}}#6c757d{{end}};
+            text-align: center;
         }
-        .section {
+        .window {
             {{if eq .Theme "dark"}}
             background-color: #343a40;
             {{else}}
             background-color: #f8f9fa;
             {{end}}
             border-radius: 8px;
-            padding: 20px;
-            margin-bottom: 20px;
+            margin: 0 auto 20px auto;
             box-shadow: 0 4px 6px rgba(0, 0, 0, 0.1);
+            overflow: auto;
         }
-        .counter {
-            font-size: 24px;
+        .window-title {
+            font-size: 14px;
             font-weight: bold;
-            margin: 20px 0;
+            padding: 8px 16px;
+            {{if eq .Theme "dark"}}
+            border-bottom: 1px solid #495057;
+            {{else}}
+            border-bottom: 1px solid #dee2e6;
+            {{end}}
         }
-        .button-row {
+        .gonic-layout {
             display: flex;
-            justify-content: center;
-            gap: 10px;
-            margin-bottom: 10px;
+            align-items: flex-start;
+        }
+        .gonic-flex-item {
+            display: flex;
+            flex-direction: column;
+            min-width: 0;
+        }
+        .gonic-flex-item > * {
+            flex: 1;
+        }
+        .gonic-grid {
+            display: grid;
+            align-items: stretch;
+        }
+        .gonic-grid-cell {
+            display: flex;
+            flex-direction: column;
+            min-width: 0;
+        }
+        .gonic-grid-cell > * {
+            flex: 1;
+        }
+        .gonic-border {
+            display: grid;
+            align-items: stretch;
+            min-height: 100%;
+            box-sizing: border-box;
+        }
+        .gonic-border > div {
+            display: flex;
+            flex-direction: column;
+            min-width: 0;
+        }
+        .gonic-border-center > :only-child {
+            flex: 1;
+        }
+        .gonic-scroll {
+            min-width: 0;
+            min-height: 0;
+        }
+        .gonic-scroll-content {
+            flex-direction: column;
+            align-items: stretch;
+            min-width: 100%;
+            box-sizing: border-box;
+        }
+        .gonic-split {
+            display: flex;
+            align-items: stretch;
+            box-sizing: border-box;
+            align-self: stretch;
+        }
+        .gonic-split-vertical {
+            min-height: 100%;
         }
-        .button {
-            padding: 10px 20px;
+        .gonic-split-pane {
+            display: flex;
+            flex-direction: column;
+            overflow: hidden;
+        }
+        .gonic-split-pane > * {
+            flex: 1;
+        }
+        .gonic-split-divider {
+            touch-action: none;
+            {{if eq .Theme "dark"}}
+            background-color: #495057;
+            {{else}}
+            background-color: #dee2e6;
+            {{end}}
+        }
+        .gonic-split-horizontal > .gonic-split-divider {
+            cursor: col-resize;
+        }
+        .gonic-split-vertical > .gonic-split-divider {
+            cursor: row-resize;
+        }
+        .gonic-event {
+            display: contents;
+        }
+        .gonic-button {
             border: none;
             border-radius: 4px;
             cursor: pointer;
             font-weight: bold;
             transition: all 0.2s;
         }
-        .primary {
-            background-color: #0073e6;
-            color: white;
+        .gonic-button:hover {
+            filter: brightness(0.9);
         }
-        .primary:hover {
-            background-color: #0056b3;
+        .gonic-button:disabled {
+            cursor: default;
+            opacity: 0.5;
         }
-        .secondary {
+        .gonic-input,
+        .gonic-select {
+            min-width: 200px;
+            padding: 6px 8px;
+            border-radius: 4px;
+            font: inherit;
             {{if eq .Theme "dark"}}
-            background-color: #495057;
-            color: white;
+            border: 1px solid #6c757d;
+            background-color: #212529;
+            color: #f8f9fa;
             {{else}}
-            background-color: #e9ecef;
-            color: #495057;
+            border: 1px solid #ced4da;
+            background-color: #ffffff;
+            color: #212529;
             {{end}}
         }
-        .secondary:hover {
-            {{if eq .Theme "dark"}}
-            background-color: #6c757d;
-            {{else}}
-            background-color: #ced4da;
-            {{end}}
+        .gonic-input[readonly] {
+            opacity: 0.7;
+        }
+        .gonic-slider {
+            min-width: 200px;
+            padding: 0;
+            border: none;
+            background: none;
+        }
+        .gonic-image {
+            display: block;
+            max-width: 100%;
+        }
+        .gonic-progress {
+            width: 200px;
+            height: 12px;
+            accent-color: #0073e6;
+        }
+        .gonic-table table {
+            border-collapse: collapse;
+        }
+        .gonic-table th,
+        .gonic-table td {
+            padding: 6px 10px;
+            text-align: left;
+            border-bottom: 1px solid {{if eq .Theme "dark"}}#495057{{else}}#dee2e6{{end}};
+        }
+        .gonic-table-select {
+            width: 1px;
+        }
+        .gonic-table-sort {
+            padding: 0;
+            border: none;
+            background: none;
+            color: inherit;
+            font: inherit;
+            font-weight: bold;
+            cursor: pointer;
+        }
+        .gonic-table-message {
+            text-align: center;
+            opacity: 0.7;
         }
-        .danger {
-            background-color: #dc3545;
+        .gonic-row-selected {
+            background-color: {{if eq .Theme "dark"}}#1c3d5a{{else}}#d6eaff{{end}};
+        }
+        .gonic-pager {
+            display: flex;
+            align-items: center;
+            gap: 10px;
+            margin-top: 8px;
+        }
+        .gonic-pager .gonic-button {
+            padding: 4px 10px;
+            background-color: #0073e6;
             color: white;
         }
-        .danger:hover {
-            background-color: #bb2d3b;
+        .gonic-list {
+            position: relative;
+            min-width: 300px;
+            overflow-y: auto;
+            border-radius: 4px;
+            border: 1px solid {{if eq .Theme "dark"}}#495057{{else}}#dee2e6{{end}};
+        }
+        .gonic-list-items {
+            position: absolute;
+            left: 0;
+            right: 0;
+        }
+        .gonic-list-item {
+            padding: 0 8px;
+            box-sizing: border-box;
+            overflow: hidden;
+            white-space: nowrap;
+            text-overflow: ellipsis;
+        }
+        .gonic-list-selectable .gonic-list-item {
+            cursor: pointer;
+        }
+        .gonic-list-item.gonic-row-selected {
+            background-color: {{if eq .Theme "dark"}}#1c3d5a{{else}}#d6eaff{{end}};
+        }
+        .gonic-tree ul {
+            list-style: none;
+            margin: 0;
+            padding-left: 18px;
+        }
+        .gonic-tree > ul {
+            padding-left: 0;
+        }
+        .gonic-tree-row {
+            display: flex;
+            align-items: center;
+            gap: 4px;
+        }
+        .gonic-tree-toggle {
+            width: 18px;
+            padding: 0;
+            border: none;
+            background: none;
+            color: inherit;
+            font: inherit;
+            cursor: pointer;
+        }
+        .gonic-tree-label {
+            padding: 2px 6px;
+            border-radius: 4px;
+            cursor: pointer;
+            user-select: none;
+        }
+        .gonic-tree-message {
+            padding: 2px 6px;
+            opacity: 0.7;
+        }
+        .gonic-menubar {
+            display: flex;
+            gap: 2px;
+            padding: 2px 8px;
+            border-bottom: 1px solid {{if eq .Theme "dark"}}#495057{{else}}#dee2e6{{end}};
+        }
+        .gonic-menu-title,
+        .gonic-menu-button {
+            display: flex;
+            align-items: center;
+            border: none;
+            background: none;
+            color: inherit;
+            font: inherit;
+            cursor: pointer;
+        }
+        .gonic-menu-title {
+            padding: 4px 10px;
+            border-radius: 4px;
+        }
+        .gonic-menu-title:hover,
+        .gonic-menu-open > .gonic-menu-title {
+            background-color: rgba(128, 128, 128, 0.2);
+        }
+        .gonic-menu-items {
+            display: none;
+            position: fixed;
+            z-index: 100;
+            min-width: 200px;
+            margin: 0;
+            padding: 4px 0;
+            list-style: none;
+            border-radius: 4px;
+            box-shadow: 0 4px 12px rgba(0, 0, 0, 0.3);
+            background-color: {{if eq .Theme "dark"}}#343a40{{else}}#ffffff{{end}};
+        }
+        .gonic-menu-open > .gonic-menu-items,
+        .gonic-submenu:hover > .gonic-menu > .gonic-menu-items {
+            display: block;
+        }
+        .gonic-submenu {
+            position: relative;
+        }
+        .gonic-submenu > .gonic-menu > .gonic-menu-title {
+            display: none;
+        }
+        .gonic-submenu .gonic-menu-items {
+            position: absolute;
+            top: -4px;
+            left: 100%;
+        }
+        .gonic-menu-button {
+            width: 100%;
+            padding: 4px 12px 4px 30px;
+            box-sizing: border-box;
+            text-align: left;
+        }
+        .gonic-submenu > .gonic-menu-button::after {
+            content: "▸";
+            margin-left: auto;
+            padding-left: 24px;
+        }
+        .gonic-menu-button:hover:enabled,
+        .gonic-submenu:hover > .gonic-menu-button {
+            background-color: {{if eq .Theme "dark"}}#1c3d5a{{else}}#d6eaff{{end}};
+        }
+        .gonic-menu-button:disabled {
+            cursor: default;
+            opacity: 0.5;
+        }
+        .gonic-menu-check {
+            width: 18px;
+            margin-left: -18px;
+        }
+        .gonic-menu-shortcut {
+            margin-left: auto;
+            padding-left: 24px;
+            opacity: 0.6;
+        }
+        .gonic-menu-separator {
+            height: 1px;
+            margin: 4px 0;
+            background-color: {{if eq .Theme "dark"}}#495057{{else}}#dee2e6{{end}};
+        }
+        .gonic-context-menu {
+            display: none;
+            position: fixed;
+            z-index: 100;
+        }
+        .gonic-context-menu.gonic-menu-open {
+            display: block;
+        }
+        .gonic-context-menu > .gonic-menu > .gonic-menu-title {
+            display: none;
+        }
+        .gonic-context-menu > .gonic-menu > .gonic-menu-items {
+            display: block;
+            position: static;
+        }
+        .gonic-tab-bar {
+            display: flex;
+            gap: 2px;
+            margin-bottom: 8px;
+            border-bottom: 1px solid {{if eq .Theme "dark"}}#495057{{else}}#dee2e6{{end}};
+        }
+        .gonic-tab {
+            padding: 6px 14px;
+            border: none;
+            border-bottom: 2px solid transparent;
+            background: none;
+            color: inherit;
+            font: inherit;
+            cursor: pointer;
+        }
+        .gonic-tab-active {
+            border-bottom-color: #0073e6;
+            font-weight: bold;
+        }
+        .gonic-nav-bar {
+            display: flex;
+            align-items: center;
+            gap: 10px;
+            margin-bottom: 8px;
+        }
+        .gonic-nav-back {
+            padding: 4px 8px;
+            border: none;
+            background: none;
+            color: #0073e6;
+            font: inherit;
+            cursor: pointer;
+        }
+        .gonic-nav-title {
+            font-weight: bold;
         }
-        .divider {
-            height: 2px;
+        .gonic-toggle {
+            display: flex;
+            align-items: center;
+            gap: 6px;
+            cursor: pointer;
+        }
+        .gonic-radio-group {
+            display: flex;
+            flex-direction: column;
+            gap: 4px;
+        }
+        .gonic-switch {
+            appearance: none;
+            -webkit-appearance: none;
+            position: relative;
+            width: 36px;
+            height: 20px;
+            margin: 0;
+            border-radius: 10px;
+            background-color: #adb5bd;
+            cursor: pointer;
+            transition: background-color 0.2s;
+        }
+        .gonic-switch::after {
+            content: "";
+            position: absolute;
+            top: 2px;
+            left: 2px;
+            width: 16px;
+            height: 16px;
+            border-radius: 50%;
+            background-color: #ffffff;
+            transition: left 0.2s;
+        }
+        .gonic-switch:checked {
+            background-color: #0073e6;
+        }
+        .gonic-switch:checked::after {
+            left: 18px;
+        }
+        .gonic-choice:disabled {
+            cursor: default;
+            opacity: 0.5;
+        }
+        .gonic-unknown {
+            margin: 0;
+            font-family: inherit;
+        }
+        .gonic-dialog-backdrop {
+            position: fixed;
+            top: 0;
+            left: 0;
+            right: 0;
+            bottom: 0;
+            display: flex;
+            align-items: center;
+            justify-content: center;
+            background-color: rgba(0, 0, 0, 0.5);
+        }
+        .gonic-dialog {
+            min-width: 300px;
+            max-width: 500px;
+            padding: 20px;
+            border-radius: 8px;
+            box-shadow: 0 4px 12px rgba(0, 0, 0, 0.3);
             {{if eq .Theme "dark"}}
-            background-color: #495057;
+            background-color: #343a40;
             {{else}}
-            background-color: #dee2e6;
+            background-color: #ffffff;
             {{end}}
-            margin: 30px 0;
+        }
+        .gonic-dialog-input {
+            display: block;
+            width: 100%;
+            box-sizing: border-box;
+            margin-bottom: 16px;
+            padding: 6px 8px;
+        }
+        .gonic-dialog-buttons {
+            display: flex;
+            flex-direction: row-reverse;
+            justify-content: flex-start;
+            gap: 10px;
+        }
+        .gonic-dialog-buttons .gonic-button {
+            padding: 8px 16px;
+            background-color: #0073e6;
+            color: white;
         }
         .footer {
             margin-top: 40px;
             font-size: 14px;
+            text-align: center;
             color: {{if eq .Theme "dark"}}#adb5bd{{else}}#6c757d{{end}};
         }
+        .footer a {
+            color: inherit;
+        }
     </style>
 </head>
 <body>
     <h1>{{.Title}}</h1>
-    <h2>Today is {{.CurrentTime}}</h2>
-
-    <div class="section">
-        <h3>Counter Example</h3>
-        <div class="counter">Count: {{.Counter.Value}}</div>
-        <div class="button-row">
-            <a href="/increment?theme={{.Theme}}"><button class="button primary">Increment</button></a>
-            <a href="/reset?theme={{.Theme}}"><button class="button secondary">Reset</button></a>
-            <a href="/decrement?theme={{.Theme}}"><button class="button danger">Decrement</button></a>
-        </div>
-    </div>
 
-    <div class="divider"></div>
-
-    <div class="section">
-        <h3>Theme Settings</h3>
-        <div class="button-row">
-            <a href="/theme?set=light"><button class="button {{if eq .Theme "light"}}primary{{else}}secondary{{end}}">Light Theme</button></a>
-            <a href="/theme?set=dark"><button class="button {{if eq .Theme "dark"}}primary{{else}}secondary{{end}}">Dark Theme</button></a>
-        </div>
-    </div>
-
-    <div class="divider"></div>
-
-    <div class="section">
-        <h3>Alert Example</h3>
-        <button class="button primary" onclick="alert('This is a sample alert message!')">Show Alert</button>
+    {{range .Windows}}
+    <div class="window" style="max-width: {{.Width}}px; min-height: {{.Height}}px;">
+        <div class="window-title">{{.Title}}</div>
+        {{.MenuBar}}
+        {{.Content}}
+        {{.ContextMenus}}
     </div>
+    {{end}}
 
     <div class="footer">
+        <p>
+            <a href="theme?set=light">Light Theme</a> |
+            <a href="theme?set=dark">Dark Theme</a>
+        </p>
         <p>Built with ❤️ using Gonic - The PyQt for Go</p>
-        <p>Version 1.0</p>
     </div>
+
+    {{range .Dialogs}}{{.}}{{end}}
+
+    <script>
+    (function () {
+        // Apply component updates pushed by the renderer
+        var stream = new EventSource("stream");
+        var lost = false;
+        stream.addEventListener("update", function (e) {
+            var update = JSON.parse(e.data);
+            var element = document.getElementById(update.id);
+            if (!element) {
+                return;
+            }
+            var scrolled = saveLists(element);
+            var areas = saveScrolls(element);
+            var focused = document.activeElement;
+            if (!focused || !focused.classList.contains("gonic-input") || !element.contains(focused)) {
+                element.outerHTML = update.html;
+                restoreLists(scrolled);
+                restoreScrolls(areas);
+                return;
+            }
+
+            // Replacing the input being typed in must not lose the caret, nor
+            // text typed after the value that the update echoes back
+            var formID = focused.form.id;
+            element.outerHTML = update.html;
+            var input = document.getElementById(formID).querySelector(".gonic-input");
+            if (input.value === focused.dataset.sent) {
+                input.value = focused.value;
+                input.dataset.sent = focused.dataset.sent;
+            }
+            input.focus();
+            if (input.value === focused.value && typeof focused.selectionStart === "number") {
+                input.setSelectionRange(focused.selectionStart, focused.selectionEnd);
+            }
+            restoreLists(scrolled);
+            restoreScrolls(areas);
+        });
+        stream.addEventListener("dialog", function (e) {
+            var dialog = JSON.parse(e.data);
+            if (!document.getElementById(dialog.id)) {
+                document.body.insertAdjacentHTML("beforeend", dialog.html);
+            }
+        });
+        stream.addEventListener("dialog-close", function (e) {
+            var element = document.getElementById(JSON.parse(e.data).id);
+            if (element) {
+                element.remove();
+            }
+        });
+        stream.onerror = function () {
+            lost = true;
+            // Streams are refused for good once the session is gone
+            if (stream.readyState === EventSource.CLOSED) {
+                location.reload();
+            }
+        };
+        stream.onopen = function () {
+            // Reload to catch up on updates missed while disconnected
+            if (lost) {
+                location.reload();
+            }
+        };
+
+        // Reload to start a new session once the renderer has ended this one
+        function reloadIfGone(response) {
+            if (response.status === 410) {
+                location.reload();
+            }
+            return response;
+        }
+
+        // Lists only hold the items scrolled into view, fetched from the renderer.
+        // Long lists scroll less than their items are tall, so the scroll
+        // position is scaled to the items and the fetched ones are placed
+        // where they show up in the view.
+        function loadList(list) {
+            var height = Number(list.dataset.itemHeight);
+            var scale = Number(list.dataset.scale) || 1;
+            var scrollTop = list.scrollTop;
+            // Items hanging below the spacer may scroll past its end
+            var end = Math.max(0, list.firstElementChild.offsetHeight - list.clientHeight);
+            var offset = Math.min(scrollTop, end) / scale;
+            var start = Math.max(0, Math.floor(offset / height) - 10);
+            var count = Math.ceil(list.clientHeight / height) + 20;
+            fetch("list?id=" + encodeURIComponent(list.id) + "&start=" + start + "&count=" + count)
+                .then(reloadIfGone)
+                .then(function (response) {
+                    return response.ok ? response.text() : null;
+                })
+                .then(function (html) {
+                    var current = document.getElementById(list.id);
+                    if (html === null || !current) {
+                        return;
+                    }
+                    current.querySelector(".gonic-list-items").outerHTML = html;
+                    if (scale < 1) {
+                        current.querySelector(".gonic-list-items").style.top = Math.round(scrollTop - (offset - start * height)) + "px";
+                    }
+                });
+        }
+        function saveLists(element) {
+            var lists = Array.prototype.slice.call(element.querySelectorAll(".gonic-list"));
+            if (element.classList.contains("gonic-list")) {
+                lists.push(element);
+            }
+            // Positions are kept in item pixels, as updates may rescale the list
+            return lists.map(function (list) {
+                return {
+                    id: list.id,
+                    top: list.scrollTop / (Number(list.dataset.scale) || 1),
+                    bottom: list.scrollTop + list.clientHeight >= list.scrollHeight - 2
+                };
+            });
+        }
+        function restoreLists(saved) {
+            saved.forEach(function (state) {
+                var list = document.getElementById(state.id);
+                if (!list) {
+                    return;
+                }
+                // Lists following their tail stay at the bottom as items are appended
+                list.scrollTop = state.bottom && list.dataset.follow === "true" ? list.scrollHeight : state.top * (Number(list.dataset.scale) || 1);
+                loadList(list);
+            });
+        }
+        var scrolling = {};
+        document.addEventListener("scroll", function (e) {
+            var list = e.target;
+            if (!list.classList || !list.classList.contains("gonic-list")) {
+                return;
+            }
+            clearTimeout(scrolling[list.id]);
+            scrolling[list.id] = setTimeout(function () {
+                loadList(list);
+            }, 50);
+        }, true);
+        Array.prototype.forEach.call(document.querySelectorAll(".gonic-list"), function (list) {
+            if (list.dataset.follow === "true") {
+                list.scrollTop = list.scrollHeight;
+            }
+            loadList(list);
+        });
+
+        // Scroll containers keep their position across updates, unless they
+        // were asked to scroll to a component since
+        function scrollToTarget(scroll) {
+            var target = document.getElementById(scroll.dataset.scrollTo);
+            if (!target || !scroll.contains(target)) {
+                return;
+            }
+            var from = scroll.getBoundingClientRect();
+            var to = target.getBoundingClientRect();
+            scroll.scrollTop += to.top - from.top;
+            scroll.scrollLeft += to.left - from.left;
+        }
+        function saveScrolls(element) {
+            var scrolls = Array.prototype.slice.call(element.querySelectorAll(".gonic-scroll"));
+            if (element.classList.contains("gonic-scroll")) {
+                scrolls.push(element);
+            }
+            return scrolls.map(function (scroll) {
+                return {
+                    id: scroll.id,
+                    top: scroll.scrollTop,
+                    left: scroll.scrollLeft,
+                    request: scroll.dataset.scrollRequest
+                };
+            });
+        }
+        function restoreScrolls(saved) {
+            saved.forEach(function (state) {
+                var scroll = document.getElementById(state.id);
+                if (!scroll) {
+                    return;
+                }
+                scroll.scrollTop = state.top;
+                scroll.scrollLeft = state.left;
+                if (scroll.dataset.scrollRequest !== state.request) {
+                    scrollToTarget(scroll);
+                }
+            });
+        }
+        Array.prototype.forEach.call(document.querySelectorAll(".gonic-scroll[data-scroll-to]"), scrollToTarget);
+
+        // Send component events without reloading the page
+        function send(action, data) {
+            fetch(action, {
+                method: "POST",
+                headers: {"X-Gonic-Live": "1"},
+                body: data
+            }).then(reloadIfGone);
+        }
+        var pending = {};
+        document.addEventListener("submit", function (e) {
+            var form = e.target;
+            if (!form.classList.contains("gonic-event")) {
+                return;
+            }
+            e.preventDefault();
+            clearTimeout(pending[form.id]);
+            var input = form.querySelector(".gonic-input");
+            if (input) {
+                input.dataset.sent = input.value;
+            }
+            var data = new URLSearchParams(new FormData(form));
+            if (e.submitter && e.submitter.name) {
+                data.set(e.submitter.name, e.submitter.value);
+            }
+            send(form.action, data);
+        });
+
+        // Send text input changes once the user pauses typing
+        document.addEventListener("input", function (e) {
+            var form = e.target.form;
+            if (!e.target.classList.contains("gonic-input") || !form) {
+                return;
+            }
+            clearTimeout(pending[form.id]);
+            pending[form.id] = setTimeout(function () {
+                var current = document.getElementById(form.id);
+                if (!current) {
+                    return;
+                }
+                var input = current.querySelector(".gonic-input");
+                input.dataset.sent = input.value;
+                send(form.action, new URLSearchParams({id: form.id, event: "change", value: input.value}));
+            }, 200);
+        });
+
+        // Checkboxes, switches and radio buttons send their state when clicked
+        document.addEventListener("change", function (e) {
+            if (e.target.classList.contains("gonic-choice") && e.target.form) {
+                e.target.form.requestSubmit();
+            }
+        });
+
+        // Clicking a list item selects it
+        document.addEventListener("click", function (e) {
+            var item = e.target.closest ? e.target.closest(".gonic-list-selectable .gonic-list-item") : null;
+            if (item) {
+                var list = item.closest(".gonic-list");
+                send("event", new URLSearchParams({id: list.id, event: "select", value: item.dataset.index}));
+            }
+        });
+
+        // Clicking a tree node selects it, clicking it again quickly counts as
+        // a double click. The tree may be redrawn between the two clicks, so the
+        // browser's own dblclick event can't be relied upon.
+        var lastClick = {};
+        document.addEventListener("click", function (e) {
+            var label = e.target.closest ? e.target.closest(".gonic-tree-label") : null;
+            if (!label) {
+                return;
+            }
+            var tree = label.closest(".gonic-tree");
+            var node = label.dataset.node;
+            var now = Date.now();
+            var double = lastClick.tree === tree.id && lastClick.node === node && now - lastClick.time < 400;
+            lastClick = double ? {} : {tree: tree.id, node: node, time: now};
+            send("event", new URLSearchParams({id: tree.id, event: double ? "dblclick" : "select", value: node}));
+        });
+
+        // Menus in the menu bar open when their title is clicked and close
+        // when anything else is clicked
+        function closeMenus(except) {
+            Array.prototype.forEach.call(document.querySelectorAll(".gonic-menu-open"), function (menu) {
+                if (menu !== except) {
+                    menu.classList.remove("gonic-menu-open");
+                }
+            });
+        }
+        document.addEventListener("click", function (e) {
+            var title = e.target.closest ? e.target.closest(".gonic-menubar > .gonic-menu > .gonic-menu-title") : null;
+            if (title) {
+                closeMenus(title.parentNode);
+                title.parentNode.classList.toggle("gonic-menu-open");
+            } else if (!e.target.closest || !e.target.closest(".gonic-submenu")) {
+                closeMenus(null);
+            }
+        });
+        document.addEventListener("mouseover", function (e) {
+            var title = e.target.closest ? e.target.closest(".gonic-menubar > .gonic-menu > .gonic-menu-title") : null;
+            var open = title ? title.closest(".gonic-menubar").querySelector(".gonic-menu-open") : null;
+            if (open && open !== title.parentNode) {
+                closeMenus(null);
+                title.parentNode.classList.add("gonic-menu-open");
+            }
+        });
+
+        // Right-clicking a component with a context menu shows it instead of
+        // the browser's menu
+        document.addEventListener("contextmenu", function (e) {
+            for (var element = e.target; element && element !== document; element = element.parentNode) {
+                var menu = element.id ? document.querySelector('.gonic-context-menu[data-for~="' + element.id + '"]') : null;
+                if (menu) {
+                    e.preventDefault();
+                    closeMenus(null);
+                    menu.style.left = e.clientX + "px";
+                    menu.style.top = e.clientY + "px";
+                    menu.classList.add("gonic-menu-open");
+                    return;
+                }
+            }
+        });
+
+        // Pressing the shortcut of a menu item clicks it
+        var shortcutKeys = {"Escape": "Esc", "Enter": "Enter", " ": "Space", "Backspace": "Backspace", "Tab": "Tab"};
+        document.addEventListener("keydown", function (e) {
+            if (e.key === "Escape") {
+                closeMenus(null);
+            }
+            var key = /^Key[A-Z]$/.test(e.code) ? e.code.charAt(3) : shortcutKeys[e.key];
+            if (!key) {
+                return;
+            }
+            var keys = [];
+            if (e.ctrlKey) {
+                keys.push("Ctrl");
+            }
+            if (e.altKey) {
+                keys.push("Alt");
+            }
+            if (e.shiftKey) {
+                keys.push("Shift");
+            }
+            if (e.metaKey) {
+                keys.push("Super");
+            }
+            // Keys without modifiers are left to the field being typed in
+            if (keys.length === 0 && /^(INPUT|TEXTAREA|SELECT)$/.test(e.target.tagName)) {
+                return;
+            }
+            keys.push(key);
+            var item = document.querySelector('.gonic-menubar .gonic-menu-item[data-shortcut="' + keys.join("+") + '"]:not(.gonic-menu-disabled)');
+            if (item) {
+                e.preventDefault();
+                send("event", new URLSearchParams({id: item.id, event: "click"}));
+            }
+        });
+
+        // Dragging the divider of a split pane resizes the leading pane as the
+        // pointer moves and sends the new ratio once it is released
+        document.addEventListener("pointerdown", function (e) {
+            var divider = e.target;
+            if (!divider.classList || !divider.classList.contains("gonic-split-divider")) {
+                return;
+            }
+            e.preventDefault();
+            var split = divider.parentNode;
+            var leading = divider.previousElementSibling;
+            var horizontal = split.classList.contains("gonic-split-horizontal");
+            var style = getComputedStyle(split);
+            var available = horizontal ?
+                split.clientWidth - parseFloat(style.paddingLeft) - parseFloat(style.paddingRight) - divider.offsetWidth :
+                split.clientHeight - parseFloat(style.paddingTop) - parseFloat(style.paddingBottom) - divider.offsetHeight;
+            var start = leading.getBoundingClientRect();
+            var ratio = Number(split.dataset.ratio);
+            function move(e) {
+                var size = horizontal ? e.clientX - start.left : e.clientY - start.top;
+                size = Math.min(size, available - Number(split.dataset.minTrailing));
+                size = Math.max(size, Number(split.dataset.minLeading), 0);
+                ratio = available > 0 ? Math.min(size / available, 1) : 0;
+                leading.style.flexBasis = size + "px";
+            }
+            function release() {
+                divider.removeEventListener("pointermove", move);
+                divider.removeEventListener("pointerup", release);
+                divider.removeEventListener("pointercancel", release);
+                if (ratio !== Number(split.dataset.ratio)) {
+                    send("event", new URLSearchParams({id: split.id, event: "ratio", value: ratio}));
+                }
+            }
+            divider.setPointerCapture(e.pointerId);
+            divider.addEventListener("pointermove", move);
+            divider.addEventListener("pointerup", release);
+            divider.addEventListener("pointercancel", release);
+        });
+
+        // Text areas submit with Ctrl+Enter, as Enter starts a new line
+        document.addEventListener("keydown", function (e) {
+            if (e.key === "Enter" && (e.ctrlKey || e.metaKey) && e.target.tagName === "TEXTAREA" &&
+                e.target.classList.contains("gonic-input")) {
+                e.preventDefault();
+                e.target.form.requestSubmit();
+            }
+        });
+    })();
+    </script>
 </body>
 </html>`
@@ -0,0 +1,245 @@
+package gonic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gonic/components"
+	"gonic/layout"
+)
+
+// get sends a GET request to a handler and returns the response
+func get(h http.Handler, target string, cookies ...*http.Cookie) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+// body reads the body of a response
+func body(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// serve sends a request to a handler and returns the recorded response
+func serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// newCounter creates a window with a label counting the clicks of a button
+func newCounter() (window *Window, label *components.Label, button *components.Button, clicks *int) {
+	clicks = new(int)
+	label = NewLabel("Count: 0")
+	button = NewButton("Add", func() {
+		*clicks++
+		label.SetText(fmt.Sprintf("Count: %d", *clicks))
+	})
+	row := NewFlexLayout()
+	row.SetDirection(layout.Horizontal)
+	row.Add(label, button)
+	window = NewWindow("Counter", 320, 240)
+	window.SetContent(row)
+	return window, label, button, clicks
+}
+
+// postEvent creates the request a live page sends when a component raises
+// an event
+func postEvent(id, event, value string) *http.Request {
+	form := url.Values{"id": {id}, "event": {event}, "value": {value}}
+	req := httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Gonic-Live", "1")
+	return req
+}
+
+func TestCloseEndsEverySessionOnce(t *testing.T) {
+	r := NewWebRenderer(0)
+	r.EnableSessions(func(s *Session) []*Window {
+		return []*Window{NewWindow("Session", 320, 240)}
+	}, 0)
+	ended := make(map[*Session]int)
+	r.OnSessionEnd(func(s *Session) {
+		ended[s]++
+	})
+
+	h := r.Handler()
+	for i := 0; i < 2; i++ {
+		if resp := get(h, "/"); resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET / returned %d", resp.StatusCode)
+		}
+	}
+
+	r.Close()
+	r.Close()
+	if len(ended) != 2 {
+		t.Fatalf("end hooks ran for %d sessions, want 2", len(ended))
+	}
+	for _, count := range ended {
+		if count != 1 {
+			t.Fatalf("end hooks ran %d times for a session, want once", count)
+		}
+	}
+}
+
+func TestOnlyThePageStartsSessions(t *testing.T) {
+	r := NewWebRenderer(0)
+	built := 0
+	r.EnableSessions(func(s *Session) []*Window {
+		built++
+		return []*Window{NewWindow("Session", 320, 240)}
+	}, 0)
+	defer r.Close()
+	h := r.Handler()
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/stream", nil),
+		httptest.NewRequest(http.MethodGet, "/list?id=gonic-1&start=0&count=10", nil),
+		httptest.NewRequest(http.MethodGet, "/asset?id=gonic-1", nil),
+		postEvent("gonic-1", "click", ""),
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusGone {
+			t.Fatalf("%s %s without a session returned %d, want %d", req.Method, req.URL, rec.Code, http.StatusGone)
+		}
+	}
+
+	// Forms posted without scripts go back to the page
+	req := postEvent("gonic-1", "click", "")
+	req.Header.Del("X-Gonic-Live")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/" {
+		t.Fatalf("form posted without a session returned %d to %q, want a redirect to /", rec.Code, rec.Header().Get("Location"))
+	}
+	if built != 0 {
+		t.Fatalf("session factory called %d times before the page was loaded", built)
+	}
+
+	resp := get(h, "/")
+	if built != 1 || len(resp.Cookies()) != 1 {
+		t.Fatalf("loading the page built %d sessions and set %d cookies, want 1", built, len(resp.Cookies()))
+	}
+	rec = httptest.NewRecorder()
+	req = postEvent("gonic-unknown", "click", "")
+	req.AddCookie(resp.Cookies()[0])
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("event for an unknown component returned %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestDialogsNeedAConnectedBrowser(t *testing.T) {
+	r := NewWebRenderer(0)
+	r.SetWindows([]*Window{NewWindow("Test", 320, 240)})
+	defer r.Close()
+
+	// Without sessions, no one answers dialogs until a browser connects
+	if response := r.ShowDialog("Sure?", "Really?", []string{"No", "Yes"}); response != -1 {
+		t.Fatalf("ShowDialog() = %d without a browser, want -1", response)
+	}
+	if _, err := r.ShowDialogContext(context.Background(), "Sure?", "Really?", []string{"OK"}); !errors.Is(err, ErrNoSessions) {
+		t.Fatalf("ShowDialogContext() returned %v without a browser, want ErrNoSessions", err)
+	}
+
+	events := r.shared.subscribe()
+	defer r.shared.unsubscribe(events)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := r.ShowDialogContext(ctx, "Sure?", "Really?", []string{"OK"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ShowDialogContext() returned %v with a browser, want it to wait for an answer", err)
+	}
+}
+
+func TestButtonClickRunsHandler(t *testing.T) {
+	window, label, button, clicks := newCounter()
+	r := NewWebRenderer(0)
+	r.SetWindows([]*Window{window})
+	defer r.Close()
+	h := r.Handler()
+
+	page := body(t, get(h, "/"))
+	id := r.shared.componentID(button)
+	for _, want := range []string{"Count: 0", `<form id="` + id + `"`, `gonic-flex"`} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("page doesn't contain %q:\n%s", want, page)
+		}
+	}
+
+	if rec := serve(h, postEvent(id, "click", "")); rec.Code != http.StatusNoContent {
+		t.Fatalf("click returned %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if *clicks != 1 || label.Text() != "Count: 1" {
+		t.Fatalf("click ran the handler %d times and the label shows %q", *clicks, label.Text())
+	}
+	if page := body(t, get(h, "/")); !strings.Contains(page, "Count: 1") {
+		t.Fatalf("page doesn't show the new count:\n%s", page)
+	}
+
+	// Pages without scripts post the form and are sent back
+	req := postEvent(id, "click", "")
+	req.Header.Del("X-Gonic-Live")
+	req.Header.Set("Referer", "/")
+	if rec := serve(h, req); rec.Code != http.StatusSeeOther || *clicks != 2 {
+		t.Fatalf("form post returned %d after %d clicks, want a redirect after 2", rec.Code, *clicks)
+	}
+
+	if rec := serve(h, postEvent(id, "change", "x")); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown event returned %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandlerUnderPrefix(t *testing.T) {
+	window, label, button, _ := newCounter()
+	r := NewWebRenderer(0)
+	r.SetBasePath("admin/ui")
+	r.SetWindows([]*Window{window})
+	defer r.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/ui/", r.Handler())
+	mux.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, "healthy")
+	})
+
+	if r.BasePath() != "/admin/ui/" {
+		t.Fatalf("BasePath() = %q, want /admin/ui/", r.BasePath())
+	}
+	if page := body(t, get(mux, "/admin/ui/")); !strings.Contains(page, "Count: 0") {
+		t.Fatalf("page under the prefix doesn't show the window:\n%s", page)
+	}
+	if resp := get(mux, "/admin/ui/missing"); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unknown path under the prefix returned %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	if page := body(t, get(mux, "/health")); page != "healthy" {
+		t.Fatalf("other routes of the server return %q", page)
+	}
+
+	req := postEvent(r.shared.componentID(button), "click", "")
+	req.URL.Path = "/admin/ui/event"
+	if rec := serve(mux, req); rec.Code != http.StatusNoContent || label.Text() != "Count: 1" {
+		t.Fatalf("click under the prefix returned %d and the label shows %q", rec.Code, label.Text())
+	}
+	req = httptest.NewRequest(http.MethodGet, "/admin/ui/theme?set=light", nil)
+	if rec := serve(mux, req); rec.Header().Get("Location") != "/admin/ui/" {
+		t.Fatalf("theme change redirects to %q, want /admin/ui/", rec.Header().Get("Location"))
+	}
+}
@@ -0,0 +1,555 @@
+package gonic
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gonic/shared"
+)
+
+// SessionFactory builds the windows shown to a new browser session
+type SessionFactory func(session *Session) []*Window
+
+// DefaultSessionTTL is how long an idle session is kept when no TTL is given
+const DefaultSessionTTL = 30 * time.Minute
+
+// sessionCookie is the name of the cookie that identifies a browser session
+const sessionCookie = "gonic_session"
+
+// ErrDialogClosed is returned when a dialog is closed without a button being clicked
+var ErrDialogClosed = errors.New("dialog closed without a response")
+
+// ErrNoSessions is returned when a dialog is shown while no browser session
+// exists, or no browser is connected to the session shared when sessions are
+// disabled
+var ErrNoSessions = errors.New("no browser session to show the dialog in")
+
+// ErrNoClients is returned when a headless app shows a dialog while no browser is connected
+var ErrNoClients = errors.New("no browser connected to show the dialog in")
+
+// Session holds the UI shown to one browser session. When sessions are
+// disabled, a single session is shared by every browser.
+type Session struct {
+	id       string
+	windows  []*Window
+	lastSeen time.Time
+	mu       sync.Mutex
+
+	// Component IDs used to route browser events back to Go
+	ids        map[shared.Component]string
+	components map[string]shared.Component
+	unwatch    map[shared.Component]func()
+	nextID     int
+	idsMu      sync.Mutex
+
+	// Components used by the full render in progress, nil between renders.
+	// Full renders take renderMu so that they don't mark for each other.
+	rendered map[shared.Component]bool
+	renderMu sync.Mutex
+
+	// Browsers connected to the live update stream
+	clients   map[chan liveEvent]struct{}
+	closed    bool
+	clientsMu sync.Mutex
+
+	// Components changed since their markup was last pushed
+	changed   []shared.Component
+	pending   map[shared.Component]bool
+	changedMu sync.Mutex
+
+	// Dialogs waiting for a response, numbered by a counter shared by the
+	// renderer's sessions
+	alerts     map[string]*AlertDialog
+	alertsMu   sync.Mutex
+	dialogSeqs *uint64
+}
+
+// newSession creates an empty session with the given ID, numbering its
+// dialogs with a counter
+func newSession(id string, dialogSeqs *uint64) *Session {
+	return &Session{
+		id:         id,
+		dialogSeqs: dialogSeqs,
+		lastSeen:   time.Now(),
+		ids:        make(map[shared.Component]string),
+		components: make(map[string]shared.Component),
+		unwatch:    make(map[shared.Component]func()),
+		clients:    make(map[chan liveEvent]struct{}),
+		pending:    make(map[shared.Component]bool),
+		alerts:     make(map[string]*AlertDialog),
+	}
+}
+
+// newSessionID returns a random, unguessable session ID
+func newSessionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// ID returns the session's ID
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Windows returns the windows shown to the session
+func (s *Session) Windows() []*Window {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.windows
+}
+
+// setWindows sets the windows shown to the session
+func (s *Session) setWindows(windows []*Window) {
+	s.mu.Lock()
+	s.windows = windows
+	s.mu.Unlock()
+}
+
+// LastSeen returns the last time a browser used the session
+func (s *Session) LastSeen() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastSeen
+}
+
+// touch records that a browser used the session
+func (s *Session) touch() {
+	s.mu.Lock()
+	s.lastSeen = time.Now()
+	s.mu.Unlock()
+}
+
+// expired reports whether the session has been idle for longer than ttl.
+// Sessions with a connected browser never expire.
+func (s *Session) expired(now time.Time, ttl time.Duration) bool {
+	return !s.hasClients() && now.Sub(s.LastSeen()) > ttl
+}
+
+// close disconnects every browser, dismisses pending dialogs and stops
+// watching the session's components
+func (s *Session) close() {
+	s.alertsMu.Lock()
+	alertIDs := make([]string, 0, len(s.alerts))
+	for id := range s.alerts {
+		alertIDs = append(alertIDs, id)
+	}
+	s.alertsMu.Unlock()
+	for _, id := range alertIDs {
+		s.closeDialog(id, -1, "")
+	}
+
+	s.clientsMu.Lock()
+	s.closed = true
+	for events := range s.clients {
+		delete(s.clients, events)
+		close(events)
+	}
+	s.clientsMu.Unlock()
+
+	s.idsMu.Lock()
+	unwatch := s.unwatch
+	s.unwatch = make(map[shared.Component]func())
+	s.idsMu.Unlock()
+	for _, cancel := range unwatch {
+		cancel()
+	}
+}
+
+// componentID returns the stable ID of a component, assigning one on first use
+func (s *Session) componentID(c shared.Component) string {
+	s.idsMu.Lock()
+	if s.rendered != nil {
+		s.rendered[c] = true
+	}
+	if id, ok := s.ids[c]; ok {
+		s.idsMu.Unlock()
+		return id
+	}
+	s.nextID++
+	id := fmt.Sprintf("gonic-%d", s.nextID)
+	s.ids[c] = id
+	s.components[id] = c
+	s.idsMu.Unlock()
+
+	// Push the component's new markup whenever it changes
+	if w, ok := c.(shared.Watchable); ok {
+		cancel := w.Watch(s.componentChanged)
+		s.idsMu.Lock()
+		s.unwatch[c] = cancel
+		s.idsMu.Unlock()
+	}
+	return id
+}
+
+// beginRender starts a full render of the session's windows, marking the
+// components it uses. It must be followed by endRender.
+func (s *Session) beginRender() {
+	s.renderMu.Lock()
+	s.idsMu.Lock()
+	s.rendered = make(map[shared.Component]bool)
+	s.idsMu.Unlock()
+}
+
+// endRender finishes a full render. Components it didn't use are no longer
+// shown, e.g. after the window content was replaced, so their IDs are
+// forgotten and their changes no longer pushed.
+func (s *Session) endRender() {
+	s.idsMu.Lock()
+	var unwatch []func()
+	for c, id := range s.ids {
+		if s.rendered[c] {
+			continue
+		}
+		delete(s.ids, c)
+		delete(s.components, id)
+		if cancel, ok := s.unwatch[c]; ok {
+			delete(s.unwatch, c)
+			unwatch = append(unwatch, cancel)
+		}
+	}
+	s.rendered = nil
+	s.idsMu.Unlock()
+	s.renderMu.Unlock()
+
+	for _, cancel := range unwatch {
+		cancel()
+	}
+}
+
+// shown reports whether a component has an ID, i.e. whether browsers show it
+func (s *Session) shown(c shared.Component) bool {
+	s.idsMu.Lock()
+	defer s.idsMu.Unlock()
+	_, ok := s.ids[c]
+	return ok
+}
+
+// componentByID returns the component with the given ID, or nil if there is none
+func (s *Session) componentByID(id string) shared.Component {
+	s.idsMu.Lock()
+	defer s.idsMu.Unlock()
+	return s.components[id]
+}
+
+// AlertDialog represents a dialog to display
+type AlertDialog struct {
+	ID       string
+	Title    string
+	Message  string
+	Buttons  []string
+	Response int
+	Done     bool
+
+	// Input dialogs ask for a line of text
+	Input       bool
+	Placeholder string
+	Value       string
+
+	seq      uint64
+	answered chan struct{}
+}
+
+// nextDialogSeq returns the number of a new dialog, unique within the renderer
+func (s *Session) nextDialogSeq() uint64 {
+	return atomic.AddUint64(s.dialogSeqs, 1)
+}
+
+// newAlertDialog creates a dialog with the given number and buttons,
+// defaulting to a single OK button
+func newAlertDialog(seq uint64, title, message string, buttons []string) *AlertDialog {
+	if len(buttons) == 0 {
+		buttons = []string{"OK"}
+	}
+	return &AlertDialog{
+		ID:       fmt.Sprintf("alert-%d", seq),
+		seq:      seq,
+		Title:    title,
+		Message:  message,
+		Buttons:  buttons,
+		answered: make(chan struct{}),
+	}
+}
+
+// newPromptDialog creates a dialog asking for a line of text
+func newPromptDialog(seq uint64, title, message, placeholder string) *AlertDialog {
+	alert := newAlertDialog(seq, title, message, []string{"Cancel", "OK"})
+	alert.Input = true
+	alert.Placeholder = placeholder
+	return alert
+}
+
+// ShowDialog displays a dialog in the session's browsers.
+// It blocks until a button is clicked and returns its index.
+func (s *Session) ShowDialog(title, message string, buttons []string) int {
+	response, _ := s.ShowDialogContext(context.Background(), title, message, buttons)
+	return response
+}
+
+// ShowDialogContext displays a dialog in the session's browsers and waits until
+// a button is clicked or the context is done. It returns the index of the
+// clicked button, or -1 and an error if the dialog was closed without an answer.
+func (s *Session) ShowDialogContext(ctx context.Context, title, message string, buttons []string) (int, error) {
+	alert := newAlertDialog(s.nextDialogSeq(), title, message, buttons)
+	if err := s.openDialog(ctx, alert); err != nil {
+		return -1, err
+	}
+	return alert.Response, nil
+}
+
+// PromptContext asks the session's browsers for a line of text. It returns the
+// text and whether OK was clicked, or an error if the dialog was closed
+// without an answer.
+func (s *Session) PromptContext(ctx context.Context, title, message, placeholder string) (string, bool, error) {
+	alert := newPromptDialog(s.nextDialogSeq(), title, message, placeholder)
+	if err := s.openDialog(ctx, alert); err != nil {
+		return "", false, err
+	}
+	return alert.Value, alert.Response == 1, nil
+}
+
+// openDialog shows a dialog in every connected browser and waits until it is
+// answered or the context is done
+func (s *Session) openDialog(ctx context.Context, alert *AlertDialog) error {
+	s.alertsMu.Lock()
+	s.alerts[alert.ID] = alert
+	s.alertsMu.Unlock()
+
+	s.broadcast("dialog", componentUpdate{ID: alert.ID, HTML: string(s.renderDialog(alert))})
+
+	select {
+	case <-alert.answered:
+		if alert.Response < 0 {
+			return ErrDialogClosed
+		}
+		return nil
+	case <-ctx.Done():
+		s.closeDialog(alert.ID, -1, "")
+		return ctx.Err()
+	}
+}
+
+// closeDialog records the response to a dialog and removes it from all browsers.
+// It reports whether the dialog was still open.
+func (s *Session) closeDialog(alertID string, response int, value string) bool {
+	s.alertsMu.Lock()
+	alert, ok := s.alerts[alertID]
+	if ok {
+		delete(s.alerts, alertID)
+		alert.Response = response
+		alert.Value = value
+		alert.Done = true
+		close(alert.answered)
+	}
+	s.alertsMu.Unlock()
+
+	if ok {
+		s.broadcast("dialog-close", componentUpdate{ID: alertID})
+	}
+	return ok
+}
+
+// pendingDialogs renders the dialogs that are still waiting for a response
+func (s *Session) pendingDialogs() []template.HTML {
+	s.alertsMu.Lock()
+	alerts := make([]*AlertDialog, 0, len(s.alerts))
+	for _, alert := range s.alerts {
+		alerts = append(alerts, alert)
+	}
+	s.alertsMu.Unlock()
+
+	// Keep dialogs in the order they were opened so the newest is on top
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].seq < alerts[j].seq })
+
+	dialogs := make([]template.HTML, 0, len(alerts))
+	for _, alert := range alerts {
+		dialogs = append(dialogs, s.renderDialog(alert))
+	}
+	return dialogs
+}
+
+// EnableSessions gives every browser session its own UI built by factory.
+// Sessions idle for longer than ttl are ended; a ttl of zero uses
+// DefaultSessionTTL. It must be called before Run.
+func (r *WebRenderer) EnableSessions(factory SessionFactory, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	r.sessionsMu.Lock()
+	r.factory = factory
+	r.sessionTTL = ttl
+	r.sessionsMu.Unlock()
+
+	// End idle sessions in the background
+	r.expireOnce.Do(func() {
+		go r.expireSessions()
+	})
+}
+
+// OnSessionEnd registers a hook that is called once for every session, after
+// it expires or the renderer is closed
+func (r *WebRenderer) OnSessionEnd(hook func(session *Session)) {
+	r.sessionsMu.Lock()
+	r.endHooks = append(r.endHooks, hook)
+	r.sessionsMu.Unlock()
+}
+
+// sessionsEnabled reports whether every browser gets its own session
+func (r *WebRenderer) sessionsEnabled() bool {
+	r.sessionsMu.Lock()
+	defer r.sessionsMu.Unlock()
+	return r.factory != nil
+}
+
+// session returns the session of the browser making the request, or nil if
+// sessions are enabled and the browser has none, e.g. because it expired.
+// Only loading the page creates sessions.
+func (r *WebRenderer) session(req *http.Request) *Session {
+	if !r.sessionsEnabled() {
+		return r.shared
+	}
+
+	cookie, err := req.Cookie(sessionCookie)
+	if err != nil {
+		return nil
+	}
+	r.sessionsMu.Lock()
+	s, ok := r.sessions[cookie.Value]
+	r.sessionsMu.Unlock()
+	if !ok {
+		return nil
+	}
+	s.touch()
+	return s
+}
+
+// pageSession returns the session of the browser loading the page, creating
+// one with the session factory if the browser doesn't have one yet
+func (r *WebRenderer) pageSession(w http.ResponseWriter, req *http.Request) *Session {
+	if s := r.session(req); s != nil {
+		return s
+	}
+
+	r.sessionsMu.Lock()
+	factory := r.factory
+	r.sessionsMu.Unlock()
+
+	// Build the new session's UI outside the lock, the factory may be slow
+	s := newSession(newSessionID(), &r.dialogSeqs)
+	s.setWindows(factory(s))
+
+	r.sessionsMu.Lock()
+	r.sessions[s.id] = s
+	r.sessionsMu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    s.id,
+		Path:     r.basePath,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return s
+}
+
+// sessionGone answers a request from a browser without a session. Forms
+// posted without scripts go back to the page, which starts a new session;
+// live pages reload when told that the session is gone.
+func (r *WebRenderer) sessionGone(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodPost && req.Header.Get("X-Gonic-Live") == "" {
+		http.Redirect(w, req, r.basePath, http.StatusSeeOther)
+		return
+	}
+	http.Error(w, "session expired", http.StatusGone)
+}
+
+// connected reports whether any browser is connected to a session's live
+// update stream
+func (r *WebRenderer) connected() bool {
+	for _, s := range r.activeSessions() {
+		if s.hasClients() {
+			return true
+		}
+	}
+	return false
+}
+
+// activeSessions returns every session that currently exists
+func (r *WebRenderer) activeSessions() []*Session {
+	if !r.sessionsEnabled() {
+		return []*Session{r.shared}
+	}
+
+	r.sessionsMu.Lock()
+	defer r.sessionsMu.Unlock()
+	sessions := make([]*Session, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		sessions = append(sessions, s)
+	}
+	return sessions
+}
+
+// expireSessions periodically ends sessions that have been idle for too long
+func (r *WebRenderer) expireSessions() {
+	r.sessionsMu.Lock()
+	interval := r.sessionTTL / 2
+	r.sessionsMu.Unlock()
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			r.sweepSessions(now)
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// sweepSessions ends every session that has expired at the given time
+func (r *WebRenderer) sweepSessions(now time.Time) {
+	r.sessionsMu.Lock()
+	expired := make([]*Session, 0)
+	for id, s := range r.sessions {
+		if s.expired(now, r.sessionTTL) {
+			delete(r.sessions, id)
+			expired = append(expired, s)
+		}
+	}
+	r.sessionsMu.Unlock()
+
+	r.endSessions(expired)
+}
+
+// endSessions closes sessions removed from the renderer and calls the end
+// hooks for them. Sessions are only removed once, so the hooks run once per
+// session.
+func (r *WebRenderer) endSessions(sessions []*Session) {
+	r.sessionsMu.Lock()
+	hooks := append([]func(session *Session){}, r.endHooks...)
+	r.sessionsMu.Unlock()
+
+	for _, s := range sessions {
+		s.close()
+		for _, hook := range hooks {
+			hook(s)
+		}
+	}
+}